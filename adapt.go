@@ -0,0 +1,116 @@
+package hashmap
+
+import (
+	"iter"
+	"maps"
+	"sync"
+)
+
+// FromMap creates a HashMap holding the entries of m, sized to hold them
+// without rehashing unless opts select another capacity.
+func FromMap[K comparable, V any](m map[K]V, opts ...Option) *HashMap[K, V] {
+	h := New[K, V](append([]Option{WithCapacity(len(m))}, opts...)...)
+	for key, value := range m {
+		h.Set(key, value)
+	}
+	return h
+}
+
+// FromSyncMap creates a HashMap holding the entries of m. Entries added
+// to m concurrently may or may not be included, as with sync.Map.Range.
+// It panics if m holds a key or value not of type K or V.
+func FromSyncMap[K comparable, V any](m *sync.Map, opts ...Option) *HashMap[K, V] {
+	h := New[K, V](opts...)
+	m.Range(func(k, v any) bool {
+		key, ok := k.(K)
+		if !ok {
+			panic("hashmap: sync.Map key has the wrong type")
+		}
+		value, ok := v.(V)
+		if !ok {
+			panic("hashmap: sync.Map value has the wrong type")
+		}
+		h.Set(key, value)
+		return true
+	})
+	return h
+}
+
+// ToMap returns the map's entries as a builtin map.
+func (h *HashMap[K, V]) ToMap() map[K]V {
+	m := make(map[K]V, h.size)
+	for s := range h.slots() {
+		m[s.Key] = s.Value
+	}
+	return m
+}
+
+// BuiltinMap exposes the API of HashMap over a builtin map, so code can
+// be moved to this package's API before switching the backing map, and
+// the two can be benchmarked side by side. Keys are compared exactly,
+// as in any builtin map.
+type BuiltinMap[K comparable, V any] struct {
+	m map[K]V
+}
+
+// NewBuiltin creates a new, empty BuiltinMap.
+func NewBuiltin[K comparable, V any]() *BuiltinMap[K, V] {
+	return &BuiltinMap[K, V]{m: make(map[K]V)}
+}
+
+// WrapMap creates a BuiltinMap backed by m, without copying it. Changes
+// through either are visible in both.
+func WrapMap[K comparable, V any](m map[K]V) *BuiltinMap[K, V] {
+	if m == nil {
+		m = make(map[K]V)
+	}
+	return &BuiltinMap[K, V]{m: m}
+}
+
+// Set inserts or updates a key-value pair.
+func (b *BuiltinMap[K, V]) Set(key K, value V) {
+	b.m[key] = value
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found, zero value and false otherwise.
+func (b *BuiltinMap[K, V]) Get(key K) (V, bool) {
+	value, found := b.m[key]
+	return value, found
+}
+
+// Contains checks if a key exists in the map.
+func (b *BuiltinMap[K, V]) Contains(key K) bool {
+	_, found := b.m[key]
+	return found
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (b *BuiltinMap[K, V]) Delete(key K) bool {
+	if _, found := b.m[key]; !found {
+		return false
+	}
+	delete(b.m, key)
+	return true
+}
+
+// Clear removes all elements from the map.
+func (b *BuiltinMap[K, V]) Clear() {
+	clear(b.m)
+}
+
+// Size returns the number of elements in the map.
+func (b *BuiltinMap[K, V]) Size() int {
+	return len(b.m)
+}
+
+// Iter returns an iterator over all key-value pairs, in unspecified order.
+func (b *BuiltinMap[K, V]) Iter() iter.Seq2[K, V] {
+	return maps.All(b.m)
+}
+
+// Unwrap returns the builtin map backing b.
+func (b *BuiltinMap[K, V]) Unwrap() map[K]V {
+	return b.m
+}
@@ -0,0 +1,52 @@
+package hashmap
+
+import "slices"
+
+// Number is a constraint matching the integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum returns the sum of the values in h, or zero if h is empty. Integer
+// sums wrap around on overflow.
+func Sum[K comparable, V Number](h *HashMap[K, V]) V {
+	var sum V
+	for s := range h.slots() {
+		sum += s.Value
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of the values in h.
+// Returns false if h is empty.
+func Mean[K comparable, V Number](h *HashMap[K, V]) (float64, bool) {
+	if h.Size() == 0 {
+		return 0, false
+	}
+
+	var sum float64
+	for s := range h.slots() {
+		sum += float64(s.Value)
+	}
+	return sum / float64(h.Size()), true
+}
+
+// Histogram counts the values in h per bucket. bounds holds the
+// inclusive upper bounds of the buckets in ascending order; counts[i]
+// is the number of values above bounds[i-1] and at most bounds[i], and
+// the extra counts[len(bounds)] is the number of values above the last
+// bound. It panics if bounds is not sorted.
+func Histogram[K comparable, V Number](h *HashMap[K, V], bounds []V) []int {
+	if !slices.IsSorted(bounds) {
+		panic("hashmap: histogram bounds are not sorted")
+	}
+
+	counts := make([]int, len(bounds)+1)
+	for s := range h.slots() {
+		i, _ := slices.BinarySearch(bounds, s.Value)
+		counts[i]++
+	}
+	return counts
+}
@@ -0,0 +1,145 @@
+package hashmap
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicMap is a HashMap for read-mostly workloads, published RCU-style
+// through an atomic pointer. Readers never lock: they look keys up in the
+// current snapshot, which is never modified once published. Writers are
+// serialized by a lock and copy the table, modify the copy and publish
+// it, so every write costs a copy of the whole table; use Update to
+// apply many writes with a single copy. Readers racing with a writer see
+// either the old or the new snapshot, never a mix.
+type AtomicMap[K comparable, V any] struct {
+	current atomic.Pointer[HashMap[K, V]] // Published snapshot, read-only
+	mu      sync.Mutex                    // Serializes writers
+	watch   *watchers[K, V]               // Created by the first Watch
+}
+
+// NewAtomic creates a new, empty AtomicMap configured by opts, like New.
+// It panics if opts include WithTimestamps or WithDeferredBuild, as both
+// modify the map on reads.
+func NewAtomic[K comparable, V any](opts ...Option) *AtomicMap[K, V] {
+	h := New[K, V](opts...)
+	if h.times != nil || h.deferred {
+		panic("hashmap: option unsupported by AtomicMap")
+	}
+	m := &AtomicMap[K, V]{}
+	m.current.Store(h)
+	return m
+}
+
+// Snapshot returns the current snapshot. It stays unchanged by later
+// writes to m and must not be modified.
+func (m *AtomicMap[K, V]) Snapshot() *HashMap[K, V] {
+	return m.current.Load()
+}
+
+// Get retrieves the value for a key without locking.
+// Returns the value and true if found, zero value and false otherwise.
+func (m *AtomicMap[K, V]) Get(key K) (V, bool) {
+	return m.Snapshot().Get(key)
+}
+
+// Contains checks if a key exists in the map without locking.
+func (m *AtomicMap[K, V]) Contains(key K) bool {
+	return m.Snapshot().Contains(key)
+}
+
+// Size returns the number of key-value pairs in the current snapshot.
+func (m *AtomicMap[K, V]) Size() int {
+	return m.Snapshot().Size()
+}
+
+// Iter returns an iterator over the key-value pairs of the snapshot that
+// is current when iteration starts. The map may be written to during
+// iteration; the writes are not observed.
+func (m *AtomicMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Snapshot().Iter()(yield)
+	}
+}
+
+// Update applies fn to a private copy of the current snapshot and
+// publishes the copy once fn returns, so readers observe all of fn's
+// writes at once. fn must not retain h or call m's write methods.
+func (m *AtomicMap[K, V]) Update(fn func(h *HashMap[K, V])) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.publish(fn)
+}
+
+// publish applies fn to a copy of the current snapshot and publishes it,
+// then passes fn's writes on to the watchers, so that they are only told
+// about changes readers can already see. A migration fn leaves pending,
+// after RotateSeed or an incremental rehash, is completed first, since
+// iterating would otherwise complete it in the shared snapshot. The
+// caller must hold m.mu.
+func (m *AtomicMap[K, V]) publish(fn func(h *HashMap[K, V])) {
+	h := m.current.Load().clone()
+	journal := h.journal
+	var changes MemoryJournal[K, V]
+	if m.watch != nil {
+		h.AddJournal(&changes)
+	}
+	fn(h)
+	h.journal = journal
+	h.migrate(h.old.len())
+	m.current.Store(h)
+
+	for _, r := range changes.Records {
+		m.watch.Append(r)
+	}
+}
+
+// Set inserts or updates a key-value pair.
+func (m *AtomicMap[K, V]) Set(key K, value V) {
+	m.Update(func(h *HashMap[K, V]) { h.Set(key, value) })
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (m *AtomicMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.current.Load().Contains(key) {
+		return false
+	}
+	m.publish(func(h *HashMap[K, V]) { h.Delete(key) })
+	return true
+}
+
+// Clear removes all elements from the map.
+func (m *AtomicMap[K, V]) Clear() {
+	m.Update(func(h *HashMap[K, V]) { h.Clear() })
+}
+
+// Watch returns a channel receiving every change to key, including
+// changes made through other spellings of key the map treats as equal,
+// and every Clear, until ctx is done; the channel is then closed. Events
+// are sent in order, once the change is visible to readers. Writers
+// never wait for watchers: events are queued for receivers that fall
+// behind, so a receiver that stops reading must cancel ctx.
+func (m *AtomicMap[K, V]) Watch(ctx context.Context, key K) <-chan Event[V] {
+	return watchKey(ctx, m.watchers(), key)
+}
+
+// WatchAll is like Watch, but receives the changes to every key as
+// records.
+func (m *AtomicMap[K, V]) WatchAll(ctx context.Context) <-chan Record[K, V] {
+	return watchAll(ctx, m.watchers())
+}
+
+// watchers returns the watchers of m, creating them on first use.
+func (m *AtomicMap[K, V]) watchers() *watchers[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.watch == nil {
+		m.watch = newWatchers(m.current.Load())
+	}
+	return m.watch
+}
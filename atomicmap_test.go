@@ -0,0 +1,53 @@
+package hashmap
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestAtomicMapReadersAfterMigration(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		update func(h *HashMap[string, int])
+	}{
+		{"rotate seed", nil, func(h *HashMap[string, int]) { h.RotateSeed() }},
+		{"incremental rehash", []Option{WithIncrementalRehash()}, func(h *HashMap[string, int]) {
+			for i := range 1000 {
+				h.Set("new"+strconv.Itoa(i), i)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAtomic[string, int](tt.opts...)
+			m.Update(func(h *HashMap[string, int]) {
+				for i := range 100 {
+					h.Set("key"+strconv.Itoa(i), i)
+				}
+			})
+			m.Update(tt.update)
+			if m.Snapshot().Migrating() {
+				t.Fatal("published snapshot is still migrating")
+			}
+
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Go(func() {
+					n := 0
+					for range m.Iter() {
+						n++
+					}
+					if n != m.Size() {
+						t.Errorf("Iter yielded %d entries, want %d", n, m.Size())
+					}
+					if v, found := m.Get("key7"); !found || v != 7 {
+						t.Errorf("Get(key7) = %d, %v, want 7, true", v, found)
+					}
+				})
+			}
+			wg.Wait()
+		})
+	}
+}
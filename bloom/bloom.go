@@ -0,0 +1,132 @@
+// Package bloom provides a Bloom filter built on the rapidhash family,
+// used to answer "definitely absent" queries without touching a table.
+package bloom
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+
+	"github.com/nukilabs/hashmap/rapidhash"
+)
+
+// Filter is a Bloom filter using Kirsch-Mitzenmacher double hashing:
+// the k bit positions are derived from the two values returned by
+// rapidhash.HashK, so the data is hashed only once per operation.
+type Filter struct {
+	bits []uint64
+	mask uint64 // Number of bits minus one, always a power of two minus one
+	k    int
+}
+
+// New creates a Filter sized to hold n elements with a false-positive
+// rate of roughly p.
+func New(n int, p float64) *Filter {
+	n = max(n, 1)
+	p = min(max(p, 1e-9), 0.5)
+
+	m := -float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)
+	k := int(math.Round(m / float64(n) * math.Ln2))
+	return NewWithSize(int(math.Ceil(m)), k)
+}
+
+// NewWithSize creates a Filter with at least m bits and k hash functions.
+// The bit count is rounded up to a power of two of at least 64.
+func NewWithSize(m, k int) *Filter {
+	size := 64
+	for size < m {
+		size *= 2
+	}
+	return &Filter{
+		bits: make([]uint64, size/64),
+		mask: uint64(size - 1),
+		k:    max(k, 1),
+	}
+}
+
+// Add inserts data into the filter.
+func (f *Filter) Add(data []byte) {
+	f.add(hashes(data))
+}
+
+// AddString inserts s into the filter.
+func (f *Filter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// AddHash inserts a precomputed hash value into the filter.
+// The two filter hashes are derived by hashing its 8-byte encoding.
+func (f *Filter) AddHash(hash uint64) {
+	f.add(hashUint64(hash))
+}
+
+// Contains reports whether data may have been added to the filter.
+// A false result is definitive; a true result may be a false positive.
+func (f *Filter) Contains(data []byte) bool {
+	return f.contains(hashes(data))
+}
+
+// ContainsString reports whether s may have been added to the filter.
+func (f *Filter) ContainsString(s string) bool {
+	return f.Contains([]byte(s))
+}
+
+// ContainsHash reports whether a precomputed hash value may have been
+// added to the filter with AddHash.
+func (f *Filter) ContainsHash(hash uint64) bool {
+	return f.contains(hashUint64(hash))
+}
+
+// Reset removes all elements from the filter.
+func (f *Filter) Reset() {
+	clear(f.bits)
+}
+
+// Clone returns a copy of the filter that can be changed independently.
+func (f *Filter) Clone() *Filter {
+	c := *f
+	c.bits = slices.Clone(f.bits)
+	return &c
+}
+
+// Bits returns the number of bits in the filter.
+func (f *Filter) Bits() int {
+	return int(f.mask + 1)
+}
+
+// K returns the number of hash functions used per element.
+func (f *Filter) K() int {
+	return f.k
+}
+
+// hashes derives the two filter hashes of data.
+func hashes(data []byte) (uint64, uint64) {
+	h := rapidhash.HashK(data, 2)
+	return h[0], h[1]
+}
+
+// hashUint64 derives the two filter hashes of a precomputed hash.
+func hashUint64(hash uint64) (uint64, uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], hash)
+	return hashes(b[:])
+}
+
+func (f *Filter) add(h1, h2 uint64) {
+	h2 |= 1 // An odd step visits k distinct bits in a power-of-two table
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) & f.mask
+		f.bits[bit>>6] |= 1 << (bit & 63)
+	}
+}
+
+func (f *Filter) contains(h1, h2 uint64) bool {
+	h2 |= 1
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) & f.mask
+		if f.bits[bit>>6]&(1<<(bit&63)) == 0 {
+			return false
+		}
+	}
+	return true
+}
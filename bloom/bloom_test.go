@@ -0,0 +1,36 @@
+package bloom
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestFilterNoFalseNegatives(t *testing.T) {
+	f := New(1000, 0.01)
+	for i := range 1000 {
+		f.AddString(strconv.Itoa(i))
+		f.AddHash(uint64(i))
+	}
+	for i := range 1000 {
+		if !f.ContainsString(strconv.Itoa(i)) {
+			t.Fatalf("ContainsString(%d) = false after AddString", i)
+		}
+		if !f.ContainsHash(uint64(i)) {
+			t.Fatalf("ContainsHash(%d) = false after AddHash", i)
+		}
+	}
+}
+
+func TestFilterDoesNotAllocate(t *testing.T) {
+	f := New(1000, 0.01)
+	data := []byte("accept-encoding")
+	allocs := testing.AllocsPerRun(100, func() {
+		f.Add(data)
+		f.Contains(data)
+		f.AddHash(42)
+		f.ContainsHash(42)
+	})
+	if allocs != 0 {
+		t.Fatalf("Add/Contains allocated %v times per run, want 0", allocs)
+	}
+}
@@ -0,0 +1,40 @@
+package hashmap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateKey is reported by Build when a key was set more than once.
+var ErrDuplicateKey = errors.New("hashmap: duplicate key")
+
+// Build places the entries collected by a map created with
+// WithDeferredBuild into a table sized for exactly that many entries,
+// and switches the map to normal operation. When a key was set more than
+// once the last value wins, and Build returns an error wrapping
+// ErrDuplicateKey that names the first such key. Build does nothing on a
+// map that is not deferred.
+func (h *HashMap[K, V]) Build() error {
+	if !h.deferred {
+		return nil
+	}
+
+	pending := h.pending
+	h.deferred, h.pending = false, nil
+	h.capacity = h.sizedCapacity(len(pending))
+	h.table = newSlotTable[K, V](h.capacity)
+	h.size, h.tombstones = 0, 0
+	if h.filter != nil {
+		h.filter = h.newFilter()
+	}
+
+	var err error
+	for _, pair := range pending {
+		size := h.size
+		h.set(pair.Key, pair.Value)
+		if h.size == size && err == nil {
+			err = fmt.Errorf("%w: %v", ErrDuplicateKey, pair.Key)
+		}
+	}
+	return err
+}
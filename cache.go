@@ -0,0 +1,166 @@
+package hashmap
+
+import "iter"
+
+// Cache is a map bounded by the total weight of its entries. By default
+// every entry weighs 1, bounding the number of entries. When an insert
+// would exceed the bound, entries chosen by the eviction policy are
+// removed to make room. String keys are matched like in HashMap.
+type Cache[K comparable, V any] struct {
+	m         *HashMap[K, V]
+	policy    EvictionPolicy[K]
+	weigher   func(K, V) int64 // Weight of an entry; every entry weighs 1 when nil
+	maxWeight int64
+	weight    int64
+	stats     CacheStats
+}
+
+// NewCache creates a Cache holding at most maxSize entries, evicting
+// according to policy. It panics if maxSize is less than 1.
+func NewCache[K comparable, V any](maxSize int, policy EvictionPolicy[K]) *Cache[K, V] {
+	if maxSize < 1 {
+		panic("hashmap: cache size must be at least 1")
+	}
+	return &Cache[K, V]{
+		m:         New[K, V](),
+		policy:    policy,
+		maxWeight: int64(maxSize),
+	}
+}
+
+// NewWeightedCache creates a Cache whose entries' weights, as reported by
+// weigher, add up to at most maxWeight, evicting according to policy.
+// The weigher must return the same non-negative weight every time it is
+// called with the same entry. It panics if maxWeight is less than 1.
+func NewWeightedCache[K comparable, V any](maxWeight int64, weigher func(K, V) int64, policy EvictionPolicy[K]) *Cache[K, V] {
+	if maxWeight < 1 {
+		panic("hashmap: cache weight must be at least 1")
+	}
+	return &Cache[K, V]{
+		m:         New[K, V](),
+		policy:    policy,
+		weigher:   weigher,
+		maxWeight: maxWeight,
+	}
+}
+
+// weigh returns the weight of an entry.
+func (c *Cache[K, V]) weigh(key K, value V) int64 {
+	if c.weigher == nil {
+		return 1
+	}
+	return c.weigher(key, value)
+}
+
+// evict removes entries chosen by the policy until an additional weight
+// of extra fits in the cache, or the cache is empty.
+func (c *Cache[K, V]) evict(extra int64) {
+	for c.weight+extra > c.maxWeight {
+		victim, ok := c.policy.Evict()
+		if !ok {
+			return
+		}
+		if value, found := c.m.Get(victim); found {
+			c.m.Delete(victim)
+			c.weight -= c.weigh(victim, value)
+			c.stats.Evictions++
+		}
+	}
+}
+
+// Set inserts or updates a key-value pair, evicting entries if it does
+// not fit. An updated entry that grew may itself be evicted if the
+// policy selects it. A new entry heavier than the whole cache is still
+// stored, after evicting everything else.
+func (c *Cache[K, V]) Set(key K, value V) {
+	weight := c.weigh(key, value)
+
+	if old, found := c.m.Get(key); found {
+		c.m.Set(key, value)
+		c.policy.Touch(key)
+		c.weight += weight - c.weigh(key, old)
+		c.evict(0)
+		return
+	}
+
+	c.evict(weight)
+	c.m.Set(key, value)
+	c.policy.Insert(key)
+	c.weight += weight
+}
+
+// Get retrieves the value for a key and records the access with the
+// eviction policy.
+// Returns the value and true if found, zero value and false otherwise.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	value, found := c.m.Get(key)
+	if found {
+		c.policy.Touch(key)
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, found
+}
+
+// Peek retrieves the value for a key without recording an access.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	return c.m.Get(key)
+}
+
+// Contains checks whether a key exists in the cache without recording
+// an access.
+func (c *Cache[K, V]) Contains(key K) bool {
+	return c.m.Contains(key)
+}
+
+// Delete removes a key-value pair from the cache.
+// Returns true if the key was found and deleted.
+func (c *Cache[K, V]) Delete(key K) bool {
+	value, found := c.m.Get(key)
+	if !found {
+		return false
+	}
+
+	c.m.Delete(key)
+	c.policy.Remove(key)
+	c.weight -= c.weigh(key, value)
+	return true
+}
+
+// Clear removes all elements from the cache.
+func (c *Cache[K, V]) Clear() {
+	for key := range c.m.Iter() {
+		c.policy.Remove(key)
+	}
+	c.m.Clear()
+	c.weight = 0
+}
+
+// CacheStats returns a snapshot of the cache's counters. Only Get counts
+// as a read; Peek and Contains do not.
+func (c *Cache[K, V]) CacheStats() CacheStats {
+	return c.stats
+}
+
+// Size returns the number of key-value pairs in the cache.
+func (c *Cache[K, V]) Size() int {
+	return c.m.Size()
+}
+
+// Weight returns the total weight of the entries in the cache.
+// For caches created with NewCache this equals Size.
+func (c *Cache[K, V]) Weight() int64 {
+	return c.weight
+}
+
+// MaxWeight returns the maximum total weight of the cache.
+// For caches created with NewCache this is the maximum number of entries.
+func (c *Cache[K, V]) MaxWeight() int64 {
+	return c.maxWeight
+}
+
+// Iter returns an iterator over key-value pairs without recording accesses.
+func (c *Cache[K, V]) Iter() iter.Seq2[K, V] {
+	return c.m.Iter()
+}
@@ -0,0 +1,34 @@
+package hashmap
+
+// WithKeyCanonicalizer makes the map pass every key given to Set, Get,
+// Contains, Delete and the other methods taking a key through fns in
+// order, and store and look up the result instead. This keeps key
+// normalization, such as strings.TrimSpace, FoldCase or Unicode
+// normalization, in one place rather than at every call site. Iteration
+// yields the canonical keys. Like any normalization, the chain must be
+// idempotent, as keys may pass through it more than once. New panics if
+// the functions do not take and return the map's key type.
+func WithKeyCanonicalizer[K comparable](fns ...func(K) K) Option {
+	return func(c *Config) {
+		c.Canonicalize = func(key K) K {
+			for _, fn := range fns {
+				key = fn(key)
+			}
+			return key
+		}
+	}
+}
+
+// FoldCase returns s with every byte folded like case-insensitive
+// hashing folds it, for use as a key canonicalizer.
+func FoldCase(s string) string {
+	return foldKey(s)
+}
+
+// canonical returns the canonical form of a key.
+func (h *HashMap[K, V]) canonical(key K) K {
+	if h.canon == nil {
+		return key
+	}
+	return h.canon(key)
+}
@@ -0,0 +1,180 @@
+// Command testvectors emits test vectors for rapidhash and CaseFoldingHash
+// as JSON, so other implementations can check parity with this package.
+//
+// The vectors are computed by this module's own Go port of rapidhash and
+// of Chromium's StringHasher and CaseFoldingHash, not taken from the C++
+// rapidhash or Chromium sources, and have not been cross-checked against
+// them. testdata/vectors.json holds the output of this command, so that
+// changes to the hashes show up as changes to the file.
+//
+// Usage:
+//
+//	go run ./cmd/testvectors > vectors.json
+//	go run ./cmd/testvectors -check vectors.json
+//
+// The -check mode recomputes every vector in the given file and reports
+// mismatches, so vectors generated on one platform can be verified on
+// another, for example with GOARCH=s390x or GOOS=js GOARCH=wasm. The
+// package tests run the same check against testdata/vectors.json:
+//
+//	GOARCH=386 go test ./cmd/testvectors
+//	GOOS=js GOARCH=wasm go test ./cmd/testvectors
+//
+// where the wasm run needs $(go env GOROOT)/lib/wasm in PATH and Node.js.
+//
+// 64-bit values are encoded as hexadecimal strings, since JSON numbers
+// cannot represent them exactly in every language.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nukilabs/hashmap/internal/stringhasher"
+	"github.com/nukilabs/hashmap/rapidhash"
+	"github.com/nukilabs/hashmap/traits"
+)
+
+// maxLength covers every branch of rapidhash: short inputs, the 16 and 32
+// byte tails, and several iterations of the 48-byte loop.
+const maxLength = 160
+
+// seeds used for the rapidhash vectors.
+var seeds = []uint64{rapidhash.SEED, 0, 1, 0xffffffffffffffff}
+
+// caseFoldingInputs are strings exercising ASCII and Latin-1 folding.
+var caseFoldingInputs = []string{
+	"",
+	"a",
+	"A",
+	"content-type",
+	"Content-Type",
+	"CONTENT-TYPE",
+	"Sec-CH-UA-Platform-Version",
+	"x-forwarded-for",
+	"\xc0\xc9\xd6\xde",
+	"\xe0\xe9\xf6\xfe",
+	"\xd7\xdf\xf7\xff",
+	strings.Repeat("Accept-Language", 8),
+}
+
+type rapidVector struct {
+	Input  string `json:"input"` // Hex-encoded bytes
+	Seed   string `json:"seed"`
+	Hash   string `json:"hash"`
+	Masked uint32 `json:"masked"` // Hash after StringHasher::MaskTop8Bits
+}
+
+type caseFoldingVector struct {
+	Input string `json:"input"` // Hex-encoded Latin-1 bytes
+	Hash  uint32 `json:"hash"`
+}
+
+type vectors struct {
+	Rapidhash   []rapidVector       `json:"rapidhash"`
+	CaseFolding []caseFoldingVector `json:"case_folding"`
+}
+
+func main() {
+	check := flag.String("check", "", "verify the vectors in `file` instead of emitting vectors")
+	flag.Parse()
+
+	if *check != "" {
+		mismatches, err := verify(*check)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if mismatches > 0 {
+			fmt.Fprintf(os.Stderr, "%d vectors do not match\n", mismatches)
+			os.Exit(1)
+		}
+		return
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(generate()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// generate computes the test vectors.
+func generate() vectors {
+	var v vectors
+
+	for _, seed := range seeds {
+		for n := 0; n <= maxLength; n++ {
+			input := make([]byte, n)
+			for i := range input {
+				input[i] = byte(i*131 + n*7 + 1)
+			}
+			hash := rapidhash.Hash(input, seed)
+			v.Rapidhash = append(v.Rapidhash, rapidVector{
+				Input:  hex.EncodeToString(input),
+				Seed:   fmt.Sprintf("0x%016x", seed),
+				Hash:   fmt.Sprintf("0x%016x", hash),
+				Masked: stringhasher.MaskTop8Bits(hash),
+			})
+		}
+	}
+
+	for _, input := range caseFoldingInputs {
+		v.CaseFolding = append(v.CaseFolding, caseFoldingVector{
+			Input: hex.EncodeToString([]byte(input)),
+			Hash:  traits.CaseFoldingHash(input),
+		})
+	}
+
+	return v
+}
+
+// verify recomputes the vectors stored in path and reports each mismatch.
+// Returns the number of mismatching vectors.
+func verify(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var v vectors
+	if err := json.Unmarshal(data, &v); err != nil {
+		return 0, err
+	}
+
+	mismatches := 0
+	for _, r := range v.Rapidhash {
+		input, err := hex.DecodeString(r.Input)
+		if err != nil {
+			return mismatches, err
+		}
+		var seed uint64
+		if _, err := fmt.Sscanf(r.Seed, "0x%x", &seed); err != nil {
+			return mismatches, fmt.Errorf("seed %q: %w", r.Seed, err)
+		}
+
+		hash := rapidhash.Hash(input, seed)
+		got := fmt.Sprintf("0x%016x", hash)
+		if got != r.Hash || stringhasher.MaskTop8Bits(hash) != r.Masked {
+			fmt.Printf("rapidhash input=%s seed=%s: got %s, want %s\n", r.Input, r.Seed, got, r.Hash)
+			mismatches++
+		}
+	}
+
+	for _, c := range v.CaseFolding {
+		input, err := hex.DecodeString(c.Input)
+		if err != nil {
+			return mismatches, err
+		}
+		if got := traits.CaseFoldingHash(string(input)); got != c.Hash {
+			fmt.Printf("case folding input=%s: got %d, want %d\n", c.Input, got, c.Hash)
+			mismatches++
+		}
+	}
+
+	return mismatches, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const vectorFile = "testdata/vectors.json"
+
+// TestVectors checks the hashes against the committed vectors. Run it on
+// other platforms to check the portable hashing path, for example with
+// GOARCH=386 or, with $(go env GOROOT)/lib/wasm in PATH, with
+// GOOS=js GOARCH=wasm.
+func TestVectors(t *testing.T) {
+	mismatches, err := verify(vectorFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mismatches > 0 {
+		t.Fatalf("%d vectors do not match %s", mismatches, vectorFile)
+	}
+}
+
+func TestVectorsUpToDate(t *testing.T) {
+	data, err := os.ReadFile(vectorFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored vectors
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, generate()) {
+		t.Fatalf("%s is out of date, regenerate it with go run ./cmd/testvectors", vectorFile)
+	}
+}
@@ -0,0 +1,48 @@
+package hashmap
+
+// CompareAndSwapFunc replaces the value of a key with new if eq reports
+// that its current value equals old, probing for the key once, so an
+// optimistic update does not have to check with Get before calling Set.
+// Returns true if the value was swapped.
+func (h *HashMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) bool {
+	s, _ := h.locate(h.canonical(key))
+	if s == nil || !eq(s.Value, old) {
+		return false
+	}
+	s.Value = new
+	h.recordSet(s.Key, new)
+	return true
+}
+
+// CompareAndDeleteFunc deletes a key if eq reports that its current value
+// equals old, probing for the key once.
+// Returns true if the key was deleted.
+func (h *HashMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) bool {
+	s, inOld := h.locate(h.canonical(key))
+	if s == nil || !eq(s.Value, old) {
+		return false
+	}
+	h.remove(s, inOld)
+	h.shrink()
+	return true
+}
+
+// CompareAndSwap replaces the value of a key in h with new if its current
+// value is old, like sync.Map.CompareAndSwap. Values of interface type
+// that are not comparable panic, as with ==.
+// Returns true if the value was swapped.
+func CompareAndSwap[K, V comparable](h *HashMap[K, V], key K, old, new V) bool {
+	return h.CompareAndSwapFunc(key, old, new, equalValues[V])
+}
+
+// CompareAndDelete deletes a key from h if its current value is old, like
+// sync.Map.CompareAndDelete.
+// Returns true if the key was deleted.
+func CompareAndDelete[K, V comparable](h *HashMap[K, V], key K, old V) bool {
+	return h.CompareAndDeleteFunc(key, old, equalValues[V])
+}
+
+// equalValues reports whether two values are equal with ==.
+func equalValues[V comparable](a, b V) bool {
+	return a == b
+}
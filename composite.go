@@ -0,0 +1,55 @@
+package hashmap
+
+import (
+	"github.com/nukilabs/hashmap/rapidhash"
+	"github.com/nukilabs/hashmap/traits"
+)
+
+// KeyField is a string component of a composite key.
+type KeyField[K any] struct {
+	Get  func(K) string // Extracts the component from a key
+	Fold bool           // Whether the component is matched case-insensitively
+}
+
+// Folded returns a KeyField matching the component extracted by get
+// case-insensitively, like HashMap's string keys.
+func Folded[K any](get func(K) string) KeyField[K] {
+	return KeyField[K]{Get: get, Fold: true}
+}
+
+// Exact returns a KeyField matching the component extracted by get
+// byte for byte.
+func Exact[K any](get func(K) string) KeyField[K] {
+	return KeyField[K]{Get: get}
+}
+
+// NewComposite creates a FuncMap whose keys are compared by the given
+// fields only, each either folded or exact. For example, a header keyed
+// by name and value can match names case-insensitively and values
+// exactly. The field hashes are combined with traits.Combine.
+func NewComposite[K, V any](fields ...KeyField[K]) *FuncMap[K, V] {
+	hash := func(key K) uint64 {
+		var h uint64
+		for _, f := range fields {
+			s := f.Get(key)
+			if f.Fold {
+				h = traits.Combine(h, uint64(traits.CaseFoldingHash(s)))
+			} else {
+				h = traits.Combine(h, rapidhash.Sum64String(s, traits.Seed))
+			}
+		}
+		return h
+	}
+
+	equal := func(a, b K) bool {
+		for _, f := range fields {
+			x, y := f.Get(a), f.Get(b)
+			if f.Fold && !traits.EqualFold(x, y) || !f.Fold && x != y {
+				return false
+			}
+		}
+		return true
+	}
+
+	return NewFunc[K, V](hash, equal)
+}
@@ -0,0 +1,138 @@
+package hashmap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// Compressor compresses and decompresses values for a CompressedCache.
+type Compressor interface {
+	Compress(src []byte) ([]byte, error)
+	Decompress(src []byte) ([]byte, error)
+}
+
+// GzipCompressor is a Compressor using gzip at the given level, as
+// accepted by gzip.NewWriterLevel. The zero value uses the default level.
+type GzipCompressor struct {
+	Level int
+}
+
+// Compress returns src compressed with gzip.
+func (g GzipCompressor) Compress(src []byte) ([]byte, error) {
+	level := g.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress returns the data compressed in src.
+func (g GzipCompressor) Decompress(src []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// compressedValue is a value stored in a CompressedCache.
+type compressedValue struct {
+	data       []byte
+	compressed bool // Whether data must be decompressed
+}
+
+// CompressedCache is a Cache of byte values bounded by their stored
+// size, which transparently compresses values of at least threshold
+// bytes. This trades CPU time on Set and Get for memory, which pays off
+// for large, compressible values such as cached response bodies.
+type CompressedCache[K comparable] struct {
+	cache      *Cache[K, compressedValue]
+	compressor Compressor
+	threshold  int
+}
+
+// NewCompressedCache creates a CompressedCache holding at most maxBytes
+// of stored, possibly compressed, values, evicting according to policy.
+// Values of at least threshold bytes are compressed with c. It panics if
+// maxBytes is less than 1.
+func NewCompressedCache[K comparable](maxBytes int64, threshold int, c Compressor, policy EvictionPolicy[K]) *CompressedCache[K] {
+	weigher := func(_ K, v compressedValue) int64 { return int64(len(v.data)) }
+	return &CompressedCache[K]{
+		cache:      NewWeightedCache(maxBytes, weigher, policy),
+		compressor: c,
+		threshold:  threshold,
+	}
+}
+
+// Set inserts or updates a value, compressing it if it is at least the
+// threshold in size. Values that do not shrink are stored uncompressed.
+func (c *CompressedCache[K]) Set(key K, value []byte) error {
+	stored := compressedValue{data: value}
+	if len(value) >= c.threshold {
+		data, err := c.compressor.Compress(value)
+		if err != nil {
+			return err
+		}
+		if len(data) < len(value) {
+			stored = compressedValue{data: data, compressed: true}
+		}
+	}
+	c.cache.Set(key, stored)
+	return nil
+}
+
+// Get retrieves and, if needed, decompresses the value for a key.
+// Returns the value and true if found, nil and false otherwise.
+func (c *CompressedCache[K]) Get(key K) ([]byte, bool, error) {
+	stored, found := c.cache.Get(key)
+	if !found || !stored.compressed {
+		return stored.data, found, nil
+	}
+
+	value, err := c.compressor.Decompress(stored.data)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Contains checks whether a key exists in the cache without recording
+// an access.
+func (c *CompressedCache[K]) Contains(key K) bool {
+	return c.cache.Contains(key)
+}
+
+// Delete removes a value from the cache.
+// Returns true if the key was found and deleted.
+func (c *CompressedCache[K]) Delete(key K) bool {
+	return c.cache.Delete(key)
+}
+
+// Size returns the number of values in the cache.
+func (c *CompressedCache[K]) Size() int {
+	return c.cache.Size()
+}
+
+// Weight returns the total stored size of the values in the cache.
+func (c *CompressedCache[K]) Weight() int64 {
+	return c.cache.Weight()
+}
+
+// CacheStats returns a snapshot of the cache's counters.
+func (c *CompressedCache[K]) CacheStats() CacheStats {
+	return c.cache.CacheStats()
+}
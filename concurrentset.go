@@ -0,0 +1,158 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"sync"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+const setShards = 32 // Number of independently locked shards of a ConcurrentSet
+
+// setShard is a part of a ConcurrentSet guarded by its own lock.
+type setShard[K comparable] struct {
+	mu      sync.RWMutex
+	m       *HashMap[K, struct{}]
+	readers int // Range calls iterating m, which writes must copy
+}
+
+// writable returns the shard's elements, first replacing them with a copy
+// if Range calls are iterating them. The caller must hold the write lock.
+func (shard *setShard[K]) writable() *HashMap[K, struct{}] {
+	if shard.readers > 0 {
+		shard.m, shard.readers = shard.m.clone(), 0
+	}
+	return shard.m
+}
+
+// ConcurrentSet is a set that is safe for concurrent use. Elements are
+// spread over independently locked shards, so goroutines working on
+// different elements rarely contend. String elements are matched like
+// HashMap keys.
+type ConcurrentSet[K comparable] struct {
+	shards [setShards]setShard[K]
+	seed   maphash.Seed // Seed for choosing the shard of non-string elements
+}
+
+// NewConcurrentSet creates a new empty ConcurrentSet.
+func NewConcurrentSet[K comparable]() *ConcurrentSet[K] {
+	s := &ConcurrentSet[K]{seed: maphash.MakeSeed()}
+	for i := range s.shards {
+		s.shards[i].m = New[K, struct{}]()
+	}
+	return s
+}
+
+// shard returns the shard holding an element. Strings are assigned by a
+// hash independent of the one the shard's table uses, so the elements of
+// a shard still spread over its whole table.
+func (s *ConcurrentSet[K]) shard(key K) *setShard[K] {
+	var hash uint64
+	switch k := any(key).(type) {
+	case string:
+		hash = uint64(traits.CaseFoldingHashWithSeed(k, secondarySeed))
+	default:
+		hash = maphash.Comparable(s.seed, key)
+	}
+	return &s.shards[hash%setShards]
+}
+
+// Add inserts an element into the set.
+// Returns true if the element was not present before.
+func (s *ConcurrentSet[K]) Add(key K) bool {
+	shard := s.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if shard.m.Contains(key) {
+		return false
+	}
+	shard.writable().Set(key, struct{}{})
+	return true
+}
+
+// Contains checks whether an element is in the set.
+func (s *ConcurrentSet[K]) Contains(key K) bool {
+	shard := s.shard(key)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	return shard.m.Contains(key)
+}
+
+// Delete removes an element from the set.
+// Returns true if the element was found and removed.
+func (s *ConcurrentSet[K]) Delete(key K) bool {
+	shard := s.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if !shard.m.Contains(key) {
+		return false
+	}
+	return shard.writable().Delete(key)
+}
+
+// Len returns the number of elements in the set. Elements added or
+// removed concurrently may or may not be counted.
+func (s *ConcurrentSet[K]) Len() int {
+	n := 0
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.RLock()
+		n += shard.m.Size()
+		shard.mu.RUnlock()
+	}
+	return n
+}
+
+// Range calls fn for every element until fn returns false. It visits a
+// point-in-time view of the set, taken by briefly locking every shard
+// when Range is called. fn is called without holding any lock, so fn may
+// use the set. Shards are shared copy-on-write with Range rather than
+// copied up front: the first write to a shard while Range is iterating
+// copies that shard alone, and other shards are not copied.
+func (s *ConcurrentSet[K]) Range(fn func(K) bool) {
+	var maps [setShards]*HashMap[K, struct{}]
+	for i := range s.shards {
+		s.shards[i].mu.Lock()
+	}
+	for i := range s.shards {
+		shard := &s.shards[i]
+		maps[i] = shard.m
+		shard.readers++
+		shard.mu.Unlock()
+	}
+
+	defer func() {
+		for i := range s.shards {
+			shard := &s.shards[i]
+			shard.mu.Lock()
+			if shard.m == maps[i] {
+				shard.readers--
+			}
+			shard.mu.Unlock()
+		}
+	}()
+	for _, m := range maps {
+		for key := range m.Keys() {
+			if !fn(key) {
+				return
+			}
+		}
+	}
+}
+
+// IsSubsetOf reports whether every element of s is in other. It stops at
+// the first element that is not. Elements added or removed concurrently
+// may or may not be taken into account.
+func (s *ConcurrentSet[K]) IsSubsetOf(other *ConcurrentSet[K]) bool {
+	subset := true
+	s.Range(func(key K) bool {
+		subset = other.Contains(key)
+		return subset
+	})
+	return subset
+}
+
+// IsSupersetOf reports whether every element of other is in s.
+func (s *ConcurrentSet[K]) IsSupersetOf(other *ConcurrentSet[K]) bool {
+	return other.IsSubsetOf(s)
+}
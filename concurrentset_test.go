@@ -0,0 +1,52 @@
+package hashmap
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSetRangeSnapshot(t *testing.T) {
+	s := NewConcurrentSet[string]()
+	for i := range 100 {
+		s.Add("key" + strconv.Itoa(i))
+	}
+
+	seen := map[string]bool{}
+	s.Range(func(key string) bool {
+		if len(seen) == 0 {
+			for i := range 100 {
+				s.Delete("key" + strconv.Itoa(i))
+				s.Add("new" + strconv.Itoa(i))
+			}
+		}
+		seen[key] = true
+		return true
+	})
+	if len(seen) != 100 {
+		t.Fatalf("Range visited %d elements, want 100", len(seen))
+	}
+	for key := range seen {
+		if key[:3] != "key" {
+			t.Fatalf("Range visited %q, added during iteration", key)
+		}
+	}
+	if s.Len() != 100 || !s.Contains("new7") || s.Contains("key7") {
+		t.Fatal("writes made during Range were lost")
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 1000 {
+			s.Add("more" + strconv.Itoa(i))
+		}
+	})
+	for range 10 {
+		n := 0
+		s.Range(func(string) bool { n++; return true })
+		if n < 100 {
+			t.Fatalf("Range visited %d elements, want at least 100", n)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,49 @@
+package hashmap
+
+// HashVersion identifies the hash functions and bucket placement of this
+// version of the package. It changes whenever a HashMap built from the
+// same HashContract could place keys differently.
+const HashVersion = 1
+
+// HashContract holds everything that determines the hashes a HashMap
+// computes and the probe sequences it follows, so that another process
+// can build a map that hashes every key identically. Two maps with equal
+// contracts and equal capacities and growth policies also assign every
+// key the same home bucket: the hash modulo the capacity, computed by
+// masking for power-of-two capacities. Contracts are plain values,
+// suitable for JSON or gob. They only cover keys of string, boolean,
+// integer and float types; other keys hash differently in every process.
+// Maps using WithHasher must also be given the same hash function.
+type HashContract struct {
+	Version       int     // HashVersion of the package that exported the contract
+	Seed          uint64  // Seed for hashing string keys
+	CaseSensitive bool    // Whether string keys hash and compare by their exact bytes
+	TwoChoice     bool    // Whether keys have a second home bucket
+	Probing       Probing // Probe sequence from the home bucket
+}
+
+// HashContract returns the map's hash contract. A migration pending after
+// RotateSeed is completed first, so that the contract covers every entry.
+func (h *HashMap[K, V]) HashContract() HashContract {
+	h.migrate(h.old.len())
+	return HashContract{
+		Version:       HashVersion,
+		Seed:          h.seed,
+		CaseSensitive: h.caseSensitive,
+		TwoChoice:     h.twoChoice,
+		Probing:       h.probing,
+	}
+}
+
+// WithHashContract makes the map hash keys exactly like the map that
+// exported c. New panics if c was exported by a version of the package
+// whose HashVersion differs.
+func WithHashContract(c HashContract) Option {
+	return func(cfg *Config) {
+		cfg.HashVersion = c.Version
+		cfg.Seed = c.Seed
+		cfg.CaseSensitive = c.CaseSensitive
+		cfg.TwoChoice = c.TwoChoice
+		cfg.Probing = c.Probing
+	}
+}
@@ -0,0 +1,115 @@
+package hashmap
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+const (
+	maxCookiePairs          = 16   // Pairs after this many are ignored, like Chromium's ParsedCookie
+	maxCookieAttributeValue = 1024 // Longer attribute values are ignored, like in Chromium
+)
+
+// cookieAttribute is an attribute stored in CookieAttributes.
+type cookieAttribute struct {
+	name  string // Spelling of the name when last set
+	value string
+	seq   int // Sequence number of the last Set, ordering iteration
+}
+
+// CookieAttributes is a map of Set-Cookie attributes such as Path, Domain
+// or SameSite. Attribute names are matched case-insensitively, as
+// RFC 6265 requires, and setting an attribute again replaces it, so the
+// last occurrence in a Set-Cookie line wins.
+type CookieAttributes struct {
+	attrs *HashMap[string, cookieAttribute] // Attributes by folded name
+	seq   int
+}
+
+// NewCookieAttributes creates an empty CookieAttributes.
+func NewCookieAttributes() *CookieAttributes {
+	return &CookieAttributes{attrs: New[string, cookieAttribute]()}
+}
+
+// ParseSetCookie parses a Set-Cookie header value the way Chromium's
+// ParsedCookie does. The line is split at semicolons into pairs, each
+// split at its first '=' and trimmed of spaces and tabs. The first pair
+// is the cookie's name and value; a first pair without '=' is a value
+// with an empty name. The remaining pairs are attributes, where a pair
+// without '=' has an empty value. Pairs beyond the 16th and attribute
+// values longer than 1024 bytes are ignored.
+func ParseSetCookie(line string) (name, value string, attrs *CookieAttributes) {
+	attrs = NewCookieAttributes()
+
+	pairs := 0
+	for pair := range strings.SplitSeq(line, ";") {
+		if pairs == maxCookiePairs {
+			break
+		}
+		pairs++
+
+		k, v, found := strings.Cut(pair, "=")
+		k, v = strings.Trim(k, " \t"), strings.Trim(v, " \t")
+		if pairs == 1 {
+			if !found {
+				k, v = "", k
+			}
+			name, value = k, v
+			continue
+		}
+		if k == "" || len(v) > maxCookieAttributeValue {
+			continue
+		}
+		attrs.Set(k, v)
+	}
+	return name, value, attrs
+}
+
+// Set sets an attribute, replacing any attribute with the same name in
+// any case.
+func (c *CookieAttributes) Set(name, value string) {
+	c.attrs.Set(foldKey(name), cookieAttribute{name: name, value: value, seq: c.seq})
+	c.seq++
+}
+
+// Get returns the value of an attribute.
+func (c *CookieAttributes) Get(name string) (string, bool) {
+	attr, found := c.attrs.Get(foldKey(name))
+	return attr.value, found
+}
+
+// Has checks whether an attribute is set. Flag attributes such as Secure
+// and HttpOnly have no value, so Has is how they are queried.
+func (c *CookieAttributes) Has(name string) bool {
+	return c.attrs.Contains(foldKey(name))
+}
+
+// Del removes an attribute.
+// Returns true if the attribute was found and removed.
+func (c *CookieAttributes) Del(name string) bool {
+	return c.attrs.Delete(foldKey(name))
+}
+
+// Len returns the number of attributes.
+func (c *CookieAttributes) Len() int {
+	return c.attrs.Size()
+}
+
+// All returns an iterator over attribute names and values in the order
+// they were last set.
+func (c *CookieAttributes) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		attrs := make([]cookieAttribute, 0, c.attrs.Size())
+		for _, attr := range c.attrs.Iter() {
+			attrs = append(attrs, attr)
+		}
+		slices.SortFunc(attrs, func(a, b cookieAttribute) int { return a.seq - b.seq })
+
+		for _, attr := range attrs {
+			if !yield(attr.name, attr.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package hashmap
+
+import "iter"
+
+// HashCountedSet is a multiset like Chromium's WTF HashCountedSet: it
+// keeps a reference count per element, which Add increments and Remove
+// decrements, and an element stays in the set until its count drops to
+// zero. Elements are told apart like HashMap keys.
+type HashCountedSet[T comparable] struct {
+	m *HashMap[T, int]
+}
+
+// NewHashCountedSet creates a new, empty HashCountedSet configured by
+// opts, like New.
+func NewHashCountedSet[T comparable](opts ...Option) *HashCountedSet[T] {
+	return &HashCountedSet[T]{m: New[T, int](opts...)}
+}
+
+// Add adds one reference to an element.
+// Returns true if the element was not present before.
+func (s *HashCountedSet[T]) Add(item T) bool {
+	return s.AddN(item, 1)
+}
+
+// AddN adds n references to an element. It panics if n is less than 1.
+// Returns true if the element was not present before.
+func (s *HashCountedSet[T]) AddN(item T, n int) bool {
+	if n < 1 {
+		panic("hashmap: count must be at least 1")
+	}
+	count, _ := s.m.Get(item)
+	s.m.Set(item, count+n)
+	return count == 0
+}
+
+// AddAll adds one reference to each of items, counting repeated items
+// once per occurrence.
+func (s *HashCountedSet[T]) AddAll(items ...T) {
+	for _, item := range items {
+		s.Add(item)
+	}
+}
+
+// Remove drops one reference to an element, removing the element once
+// its count reaches zero.
+// Returns true if the last reference was dropped and the element removed.
+func (s *HashCountedSet[T]) Remove(item T) bool {
+	count, found := s.m.Get(item)
+	if !found {
+		return false
+	}
+	if count > 1 {
+		s.m.Set(item, count-1)
+		return false
+	}
+	return s.m.Delete(item)
+}
+
+// RemoveAll removes an element regardless of its count.
+// Returns true if the element was found and removed.
+func (s *HashCountedSet[T]) RemoveAll(item T) bool {
+	return s.m.Delete(item)
+}
+
+// Count returns the number of references to an element, or zero if it is
+// not in the set.
+func (s *HashCountedSet[T]) Count(item T) int {
+	count, _ := s.m.Get(item)
+	return count
+}
+
+// Contains checks whether an element is in the set.
+func (s *HashCountedSet[T]) Contains(item T) bool {
+	return s.m.Contains(item)
+}
+
+// Size returns the number of distinct elements in the set.
+func (s *HashCountedSet[T]) Size() int {
+	return s.m.Size()
+}
+
+// Clear removes all elements from the set.
+func (s *HashCountedSet[T]) Clear() {
+	s.m.Clear()
+}
+
+// Iter returns an iterator over the elements of the set and their counts.
+func (s *HashCountedSet[T]) Iter() iter.Seq2[T, int] {
+	return s.m.Iter()
+}
@@ -0,0 +1,200 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"iter"
+
+	"github.com/nukilabs/hashmap/rapidhash"
+	"github.com/nukilabs/hashmap/traits"
+)
+
+const maxDisplacements = 64 // Displacements tried before growing
+
+// CuckooMap is a hash table using cuckoo hashing: every key lives in one
+// of two slots chosen by independent hash functions, so a lookup probes
+// at most two slots. Inserts may displace existing entries to their
+// alternate slot, which makes them slower than HashMap inserts.
+// String keys are hashed and compared case-insensitively.
+type CuckooMap[K comparable, V any] struct {
+	table    []*Pair[K, V]
+	size     int
+	capacity int
+	seeds    [2]maphash.Seed // Seeds for hashing non-string keys
+}
+
+// NewCuckoo creates a new CuckooMap with the default initial capacity.
+func NewCuckoo[K comparable, V any]() *CuckooMap[K, V] {
+	return &CuckooMap[K, V]{
+		table:    make([]*Pair[K, V], initialCapacity),
+		capacity: initialCapacity,
+		seeds:    [2]maphash.Seed{maphash.MakeSeed(), maphash.MakeSeed()},
+	}
+}
+
+// hash computes the n-th of the two hash values for a key.
+// For strings, uses case-insensitive hashing with distinct seeds.
+func (c *CuckooMap[K, V]) hash(key K, n int) uint32 {
+	switch k := any(key).(type) {
+	case string:
+		if n == 0 {
+			return traits.CaseFoldingHashWithSeed(k, rapidhash.SEED)
+		}
+		return traits.CaseFoldingHashWithSeed(k, secondarySeed)
+	default:
+		return uint32(maphash.Comparable(c.seeds[n], key))
+	}
+}
+
+// slots returns the two candidate slot indices for a key.
+func (c *CuckooMap[K, V]) slots(key K) (int, int) {
+	mask := uint32(c.capacity - 1)
+	return int(c.hash(key, 0) & mask), int(c.hash(key, 1) & mask)
+}
+
+// find locates the slot holding a key.
+// Returns the index and whether the key was found.
+func (c *CuckooMap[K, V]) find(key K) (int, bool) {
+	i1, i2 := c.slots(key)
+	if c.table[i1] != nil && equalKeys(c.table[i1].Key, key) {
+		return i1, true
+	}
+	if c.table[i2] != nil && equalKeys(c.table[i2].Key, key) {
+		return i2, true
+	}
+	return 0, false
+}
+
+// insert places a pair whose key is not yet in the table, displacing
+// entries to their alternate slots as needed. Returns nil on success,
+// or the entry left without a slot once the displacement limit is hit.
+func (c *CuckooMap[K, V]) insert(pair *Pair[K, V]) *Pair[K, V] {
+	idx, alt := c.slots(pair.Key)
+	if c.table[idx] != nil && c.table[alt] == nil {
+		idx = alt
+	}
+
+	for range maxDisplacements {
+		if c.table[idx] == nil {
+			c.table[idx] = pair
+			return nil
+		}
+
+		pair, c.table[idx] = c.table[idx], pair
+		i1, i2 := c.slots(pair.Key)
+		if idx == i1 {
+			idx = i2
+		} else {
+			idx = i1
+		}
+	}
+
+	return pair
+}
+
+// grow doubles the table until every existing entry fits.
+func (c *CuckooMap[K, V]) grow() {
+	old := c.table
+	for {
+		c.capacity *= 2
+		c.table = make([]*Pair[K, V], c.capacity)
+		if c.reinsert(old) {
+			return
+		}
+	}
+}
+
+// reinsert places every entry of old into the current table.
+// Returns false if an entry could not be placed.
+func (c *CuckooMap[K, V]) reinsert(old []*Pair[K, V]) bool {
+	for _, pair := range old {
+		if pair != nil && c.insert(pair) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Set inserts or updates a key-value pair.
+// If the key exists, only the value is updated.
+// If the key is new, both key and value are inserted.
+func (c *CuckooMap[K, V]) Set(key K, value V) {
+	idx, found := c.find(key)
+	if found {
+		c.table[idx].Value = value
+		return
+	}
+
+	if (c.size+1)*maximumLoad >= c.capacity {
+		c.grow()
+	}
+
+	pair := c.insert(&Pair[K, V]{
+		Key:   key,
+		Value: value,
+	})
+	for pair != nil {
+		c.grow()
+		pair = c.insert(pair)
+	}
+	c.size++
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found, zero value and false otherwise.
+func (c *CuckooMap[K, V]) Get(key K) (V, bool) {
+	idx, found := c.find(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return c.table[idx].Value, true
+}
+
+// Contains checks whether a key exists in the map.
+func (c *CuckooMap[K, V]) Contains(key K) bool {
+	_, found := c.find(key)
+	return found
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (c *CuckooMap[K, V]) Delete(key K) bool {
+	idx, found := c.find(key)
+	if !found {
+		return false
+	}
+
+	c.table[idx] = nil
+	c.size--
+	return true
+}
+
+// Clear removes all elements from the map.
+func (c *CuckooMap[K, V]) Clear() {
+	c.table = make([]*Pair[K, V], initialCapacity)
+	c.capacity = initialCapacity
+	c.size = 0
+}
+
+// Size returns the number of key-value pairs in the map.
+func (c *CuckooMap[K, V]) Size() int {
+	return c.size
+}
+
+// Capacity returns the current capacity of the underlying table.
+func (c *CuckooMap[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Iter returns an iterator over key-value pairs.
+func (c *CuckooMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, pair := range c.table {
+			if pair != nil {
+				if !yield(pair.Key, pair.Value) {
+					return
+				}
+			}
+		}
+	}
+}
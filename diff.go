@@ -0,0 +1,65 @@
+package hashmap
+
+// Change describes a key whose value differs between two maps.
+type Change[K comparable, V any] struct {
+	Key K
+	Old V
+	New V
+}
+
+// Diff describes the differences between two maps.
+type Diff[K comparable, V any] struct {
+	Added   []Pair[K, V]   // Entries only present in the other map
+	Removed []Pair[K, V]   // Entries only present in the receiver
+	Changed []Change[K, V] // Entries present in both with different values
+}
+
+// Empty reports whether the diff contains no changes.
+func (d Diff[K, V]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff computes the changes needed to turn the map into other.
+// Keys are matched using the map's own key identity, and values are
+// compared with eq.
+func (h *HashMap[K, V]) Diff(other *HashMap[K, V], eq func(a, b V) bool) Diff[K, V] {
+	var d Diff[K, V]
+
+	for pair := range h.slots() {
+		value, found := other.Get(pair.Key)
+		if !found {
+			d.Removed = append(d.Removed, pair.Pair)
+			continue
+		}
+		if !eq(pair.Value, value) {
+			d.Changed = append(d.Changed, Change[K, V]{
+				Key: pair.Key,
+				Old: pair.Value,
+				New: value,
+			})
+		}
+	}
+
+	for pair := range other.slots() {
+		if !h.Contains(pair.Key) {
+			d.Added = append(d.Added, pair.Pair)
+		}
+	}
+
+	return d
+}
+
+// ApplyDiff replays the changes recorded in d onto the map.
+// Added and changed entries are set to their new values, and removed
+// entries are deleted.
+func (h *HashMap[K, V]) ApplyDiff(d Diff[K, V]) {
+	for _, pair := range d.Removed {
+		h.Delete(pair.Key)
+	}
+	for _, change := range d.Changed {
+		h.Set(change.Key, change.New)
+	}
+	for _, pair := range d.Added {
+		h.Set(pair.Key, pair.Value)
+	}
+}
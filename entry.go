@@ -0,0 +1,170 @@
+package hashmap
+
+// Entry is a handle to the place of a key in a HashMap, found by hashing
+// and probing the key once. It serves read-modify-write sequences that
+// would otherwise look the key up with Get and again with Set. An Entry
+// is invalidated by any change to the map other than through AndModify,
+// including the insertion or removal made through the Entry itself.
+type Entry[K comparable, V any] struct {
+	h    *HashMap[K, V]
+	key  K
+	hash uint32
+	idx  int         // Index of the key in the table, or of the free slot for it
+	s    *slot[K, V] // Slot holding the key, or nil if the key is absent
+	old  bool        // Whether s is in the old table of a migration
+}
+
+// Entry returns the entry for a key. When the table is too full to take
+// another element it is rehashed first, as Set would, so that inserting
+// through the entry needs no further probing.
+func (h *HashMap[K, V]) Entry(key K) Entry[K, V] {
+	if h.deferred {
+		panic("hashmap: map read before Build")
+	}
+	key = h.canonical(key)
+	h.injectRehash()
+	if h.old != nil {
+		h.migrate(migrationStep)
+	}
+	if !h.fits(h.size+h.tombstones+1, h.capacity) {
+		h.rehash()
+	}
+
+	e := Entry[K, V]{h: h, key: key, hash: h.hash(key)}
+	idx, found := h.find(key, e.hash)
+	e.idx = idx
+	if found {
+		e.s = h.table.at(idx)
+	} else if h.old != nil {
+		e.s = h.lookupOld(key)
+		e.old = e.s != nil
+	}
+	return e
+}
+
+// GetOrInsert returns the value of a key, inserting value first if the
+// key is absent, probing for the key once. Like TrySet, it reports an
+// absent key rejected by key validation with an error wrapping
+// ErrInvalidKey instead of inserting it, in either validation mode.
+// Returns the value and true if the key was already present.
+func (h *HashMap[K, V]) GetOrInsert(key K, value V) (V, bool, error) {
+	e := h.Entry(key)
+	if e.s != nil {
+		return e.s.Value, true, nil
+	}
+	if err := h.checkKey(e.key); err != nil {
+		var zero V
+		return zero, false, err
+	}
+	return e.insert(value), false, nil
+}
+
+// GetOrInsertFunc is like GetOrInsert, but only calls fn to construct the
+// value to insert if the key is absent. fn must not modify the map.
+// Returns the value of the key.
+func (h *HashMap[K, V]) GetOrInsertFunc(key K, fn func() V) V {
+	return h.Entry(key).OrInsertWith(fn)
+}
+
+// Update sets the value of a key to the result of fn, which is called
+// with the current value and true if the key is present, or the zero
+// value and false if it is absent. The key is hashed and probed once,
+// unlike with Get followed by Set. fn must not modify the map.
+// Returns the value stored.
+func (h *HashMap[K, V]) Update(key K, fn func(V, bool) V) V {
+	e := h.Entry(key)
+	if e.s == nil {
+		var zero V
+		return e.insert(fn(zero, false))
+	}
+	e.s.Value = fn(e.s.Value, true)
+	h.recordSet(e.s.Key, e.s.Value)
+	return e.s.Value
+}
+
+// Key returns the key as stored in the map if present, or else the key
+// the entry was created for.
+func (e Entry[K, V]) Key() K {
+	if e.s != nil {
+		return e.s.Key
+	}
+	return e.key
+}
+
+// Value retrieves the entry's value.
+// Returns the value and true if the key is present, zero value and false
+// otherwise.
+func (e Entry[K, V]) Value() (V, bool) {
+	if e.s == nil {
+		var zero V
+		return zero, false
+	}
+	return e.s.Value, true
+}
+
+// OrInsert returns the value of the key, inserting value first if the key
+// is absent. A key rejected by the map's key validation is not inserted
+// and value is returned.
+func (e Entry[K, V]) OrInsert(value V) V {
+	if e.s != nil {
+		return e.s.Value
+	}
+	return e.insert(value)
+}
+
+// OrInsertWith is like OrInsert, but only calls fn to compute the value
+// to insert if the key is absent. fn must not modify the map.
+func (e Entry[K, V]) OrInsertWith(fn func() V) V {
+	if e.s != nil {
+		return e.s.Value
+	}
+	return e.insert(fn())
+}
+
+// AndModify calls fn with a pointer to the value of the key if it is
+// present, updating the value in place, and returns the entry so that
+// OrInsert can follow. fn must not modify the map or retain the pointer.
+func (e Entry[K, V]) AndModify(fn func(*V)) Entry[K, V] {
+	if e.s != nil {
+		fn(&e.s.Value)
+		e.h.recordSet(e.s.Key, e.s.Value)
+	}
+	return e
+}
+
+// Delete removes the key from the map.
+// Returns true if the key was present and deleted.
+func (e Entry[K, V]) Delete() bool {
+	if e.s == nil {
+		return false
+	}
+	e.h.remove(e.s, e.old)
+	e.h.shrink()
+	return true
+}
+
+// insert places the absent key with value in the free slot found for it.
+func (e Entry[K, V]) insert(value V) V {
+	h := e.h
+	if h.rejected(e.key) {
+		return value
+	}
+	if !h.fits(h.size+1, h.capacity) {
+		panic("hashmap: maximum capacity exceeded")
+	}
+
+	key := e.key
+	if h.interner != nil {
+		key = h.intern(key)
+	}
+	h.place(e.idx, slot[K, V]{
+		Pair: Pair[K, V]{Key: key, Value: value},
+		used: true,
+	})
+	h.size++
+	if h.filter != nil {
+		h.filter.AddHash(uint64(e.hash))
+	}
+	h.recordSet(key, value)
+	return value
+}
@@ -0,0 +1,25 @@
+package hashmap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetOrInsertRejectedKey(t *testing.T) {
+	for _, mode := range []KeyValidation{ValidatePanic, ValidateError} {
+		h := New[string, int](WithHeaderKeys(mode))
+		if _, _, err := h.GetOrInsert("bad name", 1); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("mode %d: GetOrInsert error = %v, want ErrInvalidKey", mode, err)
+		}
+		if h.Contains("bad name") || h.Size() != 0 {
+			t.Fatalf("mode %d: rejected key was inserted", mode)
+		}
+
+		if v, present, err := h.GetOrInsert("Accept", 1); v != 1 || present || err != nil {
+			t.Fatalf("mode %d: first GetOrInsert = %d, %v, %v, want 1, false, nil", mode, v, present, err)
+		}
+		if v, present, err := h.GetOrInsert("accept", 2); v != 1 || !present || err != nil {
+			t.Fatalf("mode %d: second GetOrInsert = %d, %v, %v, want 1, true, nil", mode, v, present, err)
+		}
+	}
+}
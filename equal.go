@@ -0,0 +1,68 @@
+package hashmap
+
+// EqualFoldKeys reports whether a and b hold the same entries when keys
+// differing only in case are treated as equal, with values compared by
+// eqV. This compares maps regardless of how each was built, for example
+// a recorded header set against a replayed one. Where a map holds
+// several case variants of one key, the other map must hold as many, and
+// their values must match pairwise in some order.
+func EqualFoldKeys[V any](a, b *HashMap[string, V], eqV func(V, V) bool) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+
+	groups := New[string, []V](WithCapacity(a.Size()))
+	for key, value := range a.Iter() {
+		folded := foldKey(key)
+		values, _ := groups.Get(folded)
+		groups.Set(folded, append(values, value))
+	}
+
+	for key, value := range b.Iter() {
+		folded := foldKey(key)
+		values, found := groups.Get(folded)
+		if !found {
+			return false
+		}
+
+		matched := -1
+		for i, v := range values {
+			if eqV(v, value) {
+				matched = i
+				break
+			}
+		}
+		if matched < 0 {
+			return false
+		}
+
+		values[matched] = values[len(values)-1]
+		if len(values) == 1 {
+			groups.Delete(folded)
+		} else {
+			groups.Set(folded, values[:len(values)-1])
+		}
+	}
+	return groups.Size() == 0
+}
+
+// IsSubsetOf reports whether every key of h is in other with a value
+// that eqV considers equal. It stops at the first entry that is not.
+func (h *HashMap[K, V]) IsSubsetOf(other *HashMap[K, V], eqV func(a, b V) bool) bool {
+	if h.Size() > other.Size() {
+		return false
+	}
+	for s := range h.slots() {
+		value, found := other.Get(s.Key)
+		if !found || !eqV(s.Value, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf reports whether every key of other is in h with a value
+// that eqV considers equal. eqV is called with h's value first.
+func (h *HashMap[K, V]) IsSupersetOf(other *HashMap[K, V], eqV func(a, b V) bool) bool {
+	return other.IsSubsetOf(h, func(a, b V) bool { return eqV(b, a) })
+}
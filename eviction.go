@@ -0,0 +1,168 @@
+package hashmap
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// EvictionPolicy decides which entry a Cache evicts when it is full.
+// The cache reports every insert, access and removal of a key, and asks
+// the policy for a victim when room is needed. Policies track keys with
+// the same identity as the cache's table.
+type EvictionPolicy[K comparable] interface {
+	// Insert records that a new key was added.
+	Insert(key K)
+	// Touch records that an existing key was read or updated.
+	Touch(key K)
+	// Remove forgets a key that was removed from the cache.
+	Remove(key K)
+	// Evict selects a key to evict and forgets it.
+	// Returns false if the policy tracks no keys.
+	Evict() (K, bool)
+}
+
+// listPolicy keeps keys in a list ordered from next victim to last.
+type listPolicy[K comparable] struct {
+	order    *list.List
+	elements *HashMap[K, *list.Element]
+	touch    bool // Whether accesses move a key to the back of the list
+}
+
+// NewLRU creates a policy evicting the least recently used key.
+func NewLRU[K comparable]() EvictionPolicy[K] {
+	return &listPolicy[K]{
+		order:    list.New(),
+		elements: New[K, *list.Element](),
+		touch:    true,
+	}
+}
+
+// NewFIFO creates a policy evicting the key inserted earliest,
+// regardless of how often it is accessed.
+func NewFIFO[K comparable]() EvictionPolicy[K] {
+	return &listPolicy[K]{
+		order:    list.New(),
+		elements: New[K, *list.Element](),
+	}
+}
+
+func (p *listPolicy[K]) Insert(key K) {
+	p.elements.Set(key, p.order.PushBack(key))
+}
+
+func (p *listPolicy[K]) Touch(key K) {
+	if !p.touch {
+		return
+	}
+	if e, found := p.elements.Get(key); found {
+		p.order.MoveToBack(e)
+	}
+}
+
+func (p *listPolicy[K]) Remove(key K) {
+	if e, found := p.elements.Get(key); found {
+		p.order.Remove(e)
+		p.elements.Delete(key)
+	}
+}
+
+func (p *listPolicy[K]) Evict() (K, bool) {
+	e := p.order.Front()
+	if e == nil {
+		var zero K
+		return zero, false
+	}
+	key := p.order.Remove(e).(K)
+	p.elements.Delete(key)
+	return key, true
+}
+
+// lfuEntry is the access record of a key tracked by lfuPolicy.
+type lfuEntry[K comparable] struct {
+	key   K
+	count uint64 // Number of inserts and accesses
+	last  uint64 // Sequence number of the latest access, breaking ties
+	index int    // Position in the heap
+}
+
+// lfuHeap is a min-heap of entries ordered by access count, then recency.
+type lfuHeap[K comparable] []*lfuEntry[K]
+
+func (q lfuHeap[K]) Len() int { return len(q) }
+
+func (q lfuHeap[K]) Less(i, j int) bool {
+	if q[i].count != q[j].count {
+		return q[i].count < q[j].count
+	}
+	return q[i].last < q[j].last
+}
+
+func (q lfuHeap[K]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *lfuHeap[K]) Push(x any) {
+	e := x.(*lfuEntry[K])
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *lfuHeap[K]) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return e
+}
+
+// lfuPolicy evicts the least frequently used key.
+type lfuPolicy[K comparable] struct {
+	queue   lfuHeap[K]
+	entries *HashMap[K, *lfuEntry[K]]
+	clock   uint64
+}
+
+// NewLFU creates a policy evicting the least frequently used key.
+// Among keys used equally often, the least recently used is evicted.
+func NewLFU[K comparable]() EvictionPolicy[K] {
+	return &lfuPolicy[K]{
+		entries: New[K, *lfuEntry[K]](),
+	}
+}
+
+func (p *lfuPolicy[K]) Insert(key K) {
+	p.clock++
+	e := &lfuEntry[K]{key: key, count: 1, last: p.clock}
+	heap.Push(&p.queue, e)
+	p.entries.Set(key, e)
+}
+
+func (p *lfuPolicy[K]) Touch(key K) {
+	e, found := p.entries.Get(key)
+	if !found {
+		return
+	}
+	p.clock++
+	e.count++
+	e.last = p.clock
+	heap.Fix(&p.queue, e.index)
+}
+
+func (p *lfuPolicy[K]) Remove(key K) {
+	if e, found := p.entries.Get(key); found {
+		heap.Remove(&p.queue, e.index)
+		p.entries.Delete(key)
+	}
+}
+
+func (p *lfuPolicy[K]) Evict() (K, bool) {
+	if len(p.queue) == 0 {
+		var zero K
+		return zero, false
+	}
+	e := heap.Pop(&p.queue).(*lfuEntry[K])
+	p.entries.Delete(e.key)
+	return e.key, true
+}
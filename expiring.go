@@ -0,0 +1,371 @@
+package hashmap
+
+import (
+	"context"
+	"iter"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// expiringEntry is a value stored in an ExpiringMap with its deadline.
+type expiringEntry[V any] struct {
+	value   V
+	expires time.Time // Zero if the entry never expires
+}
+
+// expired reports whether the entry has expired at now.
+func (e expiringEntry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// ExpiringMap is a map whose entries expire after a time-to-live.
+// Expired entries are never returned; they are removed lazily when
+// accessed, by Sweep, or by an optional background janitor. Unlike
+// HashMap, ExpiringMap is safe for concurrent use, since the janitor
+// runs on its own goroutine.
+type ExpiringMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	m       *HashMap[K, expiringEntry[V]]
+	readers int // Iterations over m in progress, which writes must copy
+	ttl     time.Duration
+	stop    chan struct{}             // Closed to stop the janitor
+	done    chan struct{}             // Closed when the janitor has exited
+	loads   *HashMap[K, *loadCall[V]] // In-flight GetOrLoad calls, created on first use
+
+	onExpire func(K, V)   // Optional callback for expired entries
+	expired  []Pair[K, V] // Expired entries to report once the lock is released
+	stats    CacheStats
+}
+
+// loadCall is an in-flight GetOrLoad loader call, shared by concurrent
+// callers with the same key.
+type loadCall[V any] struct {
+	ready chan struct{} // Closed when the loader has finished
+	value V
+	err   error
+	done  bool // Whether the loader returned rather than panicked
+}
+
+// NewExpiring creates a new ExpiringMap whose entries expire ttl after
+// they are set. A ttl of zero or less means entries set with Set never
+// expire.
+func NewExpiring[K comparable, V any](ttl time.Duration) *ExpiringMap[K, V] {
+	return &ExpiringMap[K, V]{
+		m:   New[K, expiringEntry[V]](),
+		ttl: ttl,
+	}
+}
+
+// deadline returns the expiry time for an entry set now with ttl.
+func deadline(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// get returns the live entry for a key, removing it if it has expired.
+// The caller must hold the lock.
+func (e *ExpiringMap[K, V]) get(key K) (expiringEntry[V], bool) {
+	entry, found := e.m.Get(key)
+	if !found {
+		return entry, false
+	}
+	if entry.expired(time.Now()) {
+		e.expire(key, entry)
+		return expiringEntry[V]{}, false
+	}
+	return entry, true
+}
+
+// writable returns the map holding the entries, first replacing it with
+// a copy if iterations over it are in progress. The caller must hold the
+// lock.
+func (e *ExpiringMap[K, V]) writable() *HashMap[K, expiringEntry[V]] {
+	if e.readers > 0 {
+		e.m, e.readers = e.m.clone(), 0
+	}
+	return e.m
+}
+
+// expire removes an expired entry, queueing it for the OnExpire callback.
+// The caller must hold the lock.
+func (e *ExpiringMap[K, V]) expire(key K, entry expiringEntry[V]) {
+	e.writable().Delete(key)
+	e.stats.Evictions++
+	if e.onExpire != nil {
+		e.expired = append(e.expired, Pair[K, V]{Key: key, Value: entry.value})
+	}
+}
+
+// unlock releases the lock and then reports the entries that expired
+// while it was held, so the callback may use the map.
+func (e *ExpiringMap[K, V]) unlock() {
+	expired, fn := e.expired, e.onExpire
+	e.expired = nil
+	e.mu.Unlock()
+
+	for _, pair := range expired {
+		fn(pair.Key, pair.Value)
+	}
+}
+
+// OnExpire registers fn to be called for every entry removed because it
+// expired, whether on access, by Sweep or by the janitor, so resources
+// held by values can be released. Entries removed by Delete or Clear, or
+// replaced by Set, are not reported. fn is called without the map's lock
+// held and replaces any previously registered callback.
+func (e *ExpiringMap[K, V]) OnExpire(fn func(K, V)) {
+	e.mu.Lock()
+	defer e.unlock()
+	e.onExpire = fn
+}
+
+// Set inserts or updates a key-value pair using the map's default TTL.
+func (e *ExpiringMap[K, V]) Set(key K, value V) {
+	e.SetWithTTL(key, value, e.ttl)
+}
+
+// SetWithTTL inserts or updates a key-value pair that expires after ttl,
+// overriding the map's default. A ttl of zero or less means the entry
+// never expires.
+func (e *ExpiringMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	e.mu.Lock()
+	defer e.unlock()
+	e.writable().Set(key, expiringEntry[V]{value: value, expires: deadline(ttl)})
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found and not expired, zero value and
+// false otherwise.
+func (e *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	e.mu.Lock()
+	defer e.unlock()
+	entry, found := e.get(key)
+	e.count(found)
+	return entry.value, found
+}
+
+// count records a read with the map's counters.
+// The caller must hold the lock.
+func (e *ExpiringMap[K, V]) count(found bool) {
+	if found {
+		e.stats.Hits++
+	} else {
+		e.stats.Misses++
+	}
+}
+
+// GetOrLoad retrieves the value for a key, calling loader to produce it
+// if the key is missing or has expired. A loaded value is set with the
+// map's default TTL; an error is returned to the caller and not cached.
+// Concurrent callers with the same missing key share a single loader
+// call instead of each calling it, so a popular key expiring does not
+// stampede the source it is loaded from. If the loader panics, callers
+// waiting on it retry.
+func (e *ExpiringMap[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	return e.GetOrLoadCtx(context.Background(), key, func(_ context.Context, key K) (V, error) {
+		return loader(key)
+	})
+}
+
+// GetOrLoadCtx is like GetOrLoad, but passes ctx to the loader. A caller
+// waiting for a load started by another caller stops waiting and returns
+// ctx.Err() when ctx is done; the load itself carries on for the others.
+func (e *ExpiringMap[K, V]) GetOrLoadCtx(ctx context.Context, key K, loader func(context.Context, K) (V, error)) (V, error) {
+	e.mu.Lock()
+	entry, found := e.get(key)
+	e.count(found)
+	if found {
+		e.unlock()
+		return entry.value, nil
+	}
+
+	if e.loads == nil {
+		e.loads = New[K, *loadCall[V]]()
+	}
+	if c, found := e.loads.Get(key); found {
+		e.unlock()
+		select {
+		case <-c.ready:
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+		if !c.done {
+			return e.GetOrLoadCtx(ctx, key, loader)
+		}
+		return c.value, c.err
+	}
+
+	c := &loadCall[V]{ready: make(chan struct{})}
+	e.loads.Set(key, c)
+	e.unlock()
+
+	defer func() {
+		e.mu.Lock()
+		e.loads.Delete(key)
+		if c.done {
+			e.stats.recordLoad(c.err)
+		}
+		if c.done && c.err == nil {
+			e.writable().Set(key, expiringEntry[V]{value: c.value, expires: deadline(e.ttl)})
+		}
+		e.unlock()
+		close(c.ready)
+	}()
+	c.value, c.err = loader(ctx, key)
+	c.done = true
+	return c.value, c.err
+}
+
+// Contains checks whether an unexpired key exists in the map.
+func (e *ExpiringMap[K, V]) Contains(key K) bool {
+	e.mu.Lock()
+	defer e.unlock()
+	_, found := e.get(key)
+	return found
+}
+
+// ExpiresAt returns the time at which a key expires.
+// The time is zero if the key never expires. Returns false if the key
+// does not exist or has already expired.
+func (e *ExpiringMap[K, V]) ExpiresAt(key K) (time.Time, bool) {
+	e.mu.Lock()
+	defer e.unlock()
+	entry, found := e.get(key)
+	return entry.expires, found
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if an unexpired key was found and deleted.
+func (e *ExpiringMap[K, V]) Delete(key K) bool {
+	e.mu.Lock()
+	defer e.unlock()
+	if _, found := e.get(key); !found {
+		return false
+	}
+	return e.writable().Delete(key)
+}
+
+// Clear removes all elements from the map.
+func (e *ExpiringMap[K, V]) Clear() {
+	e.mu.Lock()
+	defer e.unlock()
+	e.writable().Clear()
+}
+
+// CacheStats returns a snapshot of the map's counters. Get and GetOrLoad
+// count as reads, and expired entries count as evictions.
+func (e *ExpiringMap[K, V]) CacheStats() CacheStats {
+	e.mu.Lock()
+	defer e.unlock()
+	return e.stats
+}
+
+// Size returns the number of entries in the map, including expired
+// entries that have not been removed yet.
+func (e *ExpiringMap[K, V]) Size() int {
+	e.mu.Lock()
+	defer e.unlock()
+	return e.m.Size()
+}
+
+// Sweep removes all expired entries and returns how many were removed.
+func (e *ExpiringMap[K, V]) Sweep() int {
+	e.mu.Lock()
+	defer e.unlock()
+
+	now := time.Now()
+	var expired []Pair[K, expiringEntry[V]]
+	for key, entry := range e.m.Iter() {
+		if entry.expired(now) {
+			expired = append(expired, Pair[K, expiringEntry[V]]{Key: key, Value: entry})
+		}
+	}
+	for _, pair := range expired {
+		e.expire(pair.Key, pair.Value)
+	}
+	return len(expired)
+}
+
+// StartJanitor starts a background goroutine calling Sweep every interval,
+// plus a random delay of up to jitter so that many maps started together
+// do not sweep in lockstep. Any running janitor is stopped first.
+// It panics if interval is not positive.
+func (e *ExpiringMap[K, V]) StartJanitor(interval, jitter time.Duration) {
+	if interval <= 0 {
+		panic("hashmap: janitor interval must be positive")
+	}
+
+	e.Stop()
+
+	e.mu.Lock()
+	defer e.unlock()
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	go e.janitor(interval, jitter, e.stop, e.done)
+}
+
+// janitor sweeps the map periodically until stop is closed.
+func (e *ExpiringMap[K, V]) janitor(interval, jitter time.Duration, stop, done chan struct{}) {
+	defer close(done)
+
+	for {
+		wait := interval
+		if jitter > 0 {
+			wait += rand.N(jitter)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			e.Sweep()
+		}
+	}
+}
+
+// Stop stops the background janitor, if running, and waits for it to exit.
+func (e *ExpiringMap[K, V]) Stop() {
+	e.mu.Lock()
+	stop, done := e.stop, e.done
+	e.stop, e.done = nil, nil
+	e.unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Iter returns an iterator over the key-value pairs unexpired when
+// iteration starts. It iterates over a point-in-time view of the map
+// without holding the lock, so the map may be used freely from within
+// the loop. Instead of copying the map up front, the first write during
+// iteration copies it.
+func (e *ExpiringMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		e.mu.Lock()
+		now := time.Now()
+		h := e.m
+		e.readers++
+		e.unlock()
+
+		defer func() {
+			e.mu.Lock()
+			if e.m == h {
+				e.readers--
+			}
+			e.unlock()
+		}()
+		for key, entry := range h.Iter() {
+			if !entry.expired(now) && !yield(key, entry.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package hashmap
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestExpiringMapIterSnapshot(t *testing.T) {
+	e := NewExpiring[string, int](0)
+	for i := range 100 {
+		e.Set("key"+strconv.Itoa(i), i)
+	}
+
+	seen := map[string]int{}
+	for key, value := range e.Iter() {
+		if len(seen) == 0 {
+			e.Clear()
+			e.Set("new", -1)
+		}
+		seen[key] = value
+	}
+	if len(seen) != 100 {
+		t.Fatalf("Iter yielded %d entries, want 100", len(seen))
+	}
+	for key, value := range seen {
+		if key != "key"+strconv.Itoa(value) {
+			t.Fatalf("Iter yielded %q: %d", key, value)
+		}
+	}
+	if e.Size() != 1 || !e.Contains("new") {
+		t.Fatal("writes made during Iter were lost")
+	}
+}
@@ -0,0 +1,37 @@
+package hashmap
+
+import (
+	"errors"
+	"slices"
+)
+
+// Faults describes pathological conditions injected into every HashMap,
+// so packages built on it can deterministically test how they behave
+// when the table misbehaves. Faults can only be injected in binaries
+// built with the hashmapfaults build tag, such as by
+// go test -tags hashmapfaults; other builds compile the hooks away.
+type Faults struct {
+	Collide  bool                    // Whether every key hashes to the same value
+	RehashAt []int                   // Sizes at which Set rehashes the table in place
+	FailGrow func(capacity int) bool // Reports whether allocating a table of capacity fails
+}
+
+// ErrAllocation is the panic value of a simulated allocation failure.
+// The map is left unchanged when it is raised.
+var ErrAllocation = errors.New("hashmap: simulated allocation failure")
+
+// injectRehash rehashes the table at its current capacity if the faults
+// in effect ask for a rehash at the current size.
+func (h *HashMap[K, V]) injectRehash() {
+	if f := activeFaults(); f != nil && !h.deferred && slices.Contains(f.RehashAt, h.size) {
+		h.resize(h.capacity)
+	}
+}
+
+// injectAllocFailure panics with ErrAllocation if the faults in effect
+// fail allocating a table of the given capacity.
+func injectAllocFailure(capacity int) {
+	if f := activeFaults(); f != nil && f.FailGrow != nil && f.FailGrow(capacity) {
+		panic(ErrAllocation)
+	}
+}
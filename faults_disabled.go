@@ -0,0 +1,9 @@
+//go:build !hashmapfaults
+
+package hashmap
+
+// activeFaults returns nil, since faults cannot be injected without the
+// hashmapfaults build tag.
+func activeFaults() *Faults {
+	return nil
+}
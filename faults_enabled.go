@@ -0,0 +1,21 @@
+//go:build hashmapfaults
+
+package hashmap
+
+import "sync/atomic"
+
+// faults holds the faults in effect, or nil.
+var faults atomic.Pointer[Faults]
+
+// InjectFaults makes every HashMap suffer f until the returned function
+// is called, which restores the faults previously in effect. It is only
+// available in builds with the hashmapfaults build tag.
+func InjectFaults(f Faults) (restore func()) {
+	prev := faults.Swap(&f)
+	return func() { faults.Store(prev) }
+}
+
+// activeFaults returns the faults in effect, or nil.
+func activeFaults() *Faults {
+	return faults.Load()
+}
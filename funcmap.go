@@ -0,0 +1,172 @@
+package hashmap
+
+import "iter"
+
+// funcEntry is a slot of a FuncMap's table. The key's hash is cached so
+// that rehashing does not call the hash function again and most probes
+// for other keys are rejected without calling the equality function.
+type funcEntry[K, V any] struct {
+	key     K
+	value   V
+	hash    uint64
+	used    bool
+	deleted bool // Whether the slot is a tombstone, as in HashMap
+}
+
+// FuncMap is a hash table for keys that are not comparable with ==, such
+// as slices or structs containing maps. Keys are hashed and compared with
+// caller-provided functions, which must agree: keys that are equal must
+// have the same hash. Collisions are resolved by quadratic probing like
+// in HashMap.
+type FuncMap[K, V any] struct {
+	table      []funcEntry[K, V]
+	size       int
+	capacity   int
+	tombstones int // Number of tombstones in table
+	hash       func(K) uint64
+	equal      func(a, b K) bool
+}
+
+// NewFunc creates a new FuncMap with the default initial capacity that
+// hashes keys with hash and compares them with equal.
+func NewFunc[K, V any](hash func(K) uint64, equal func(a, b K) bool) *FuncMap[K, V] {
+	return &FuncMap[K, V]{
+		table:    make([]funcEntry[K, V], initialCapacity),
+		capacity: initialCapacity,
+		hash:     hash,
+		equal:    equal,
+	}
+}
+
+// find locates the slot for a key with the given hash using quadratic
+// probing past tombstones. For a missing key the first tombstone passed
+// is returned. Returns the index and whether the key was found.
+func (m *FuncMap[K, V]) find(key K, hash uint64) (int, bool) {
+	mask := m.capacity - 1
+	idx := int(hash) & mask
+	free := -1
+
+	for count := 1; count <= m.capacity; count++ {
+		entry := &m.table[idx]
+		switch {
+		case entry.used:
+			if entry.hash == hash && m.equal(entry.key, key) {
+				return idx, true
+			}
+		case !entry.deleted:
+			if free >= 0 {
+				return free, false
+			}
+			return idx, false
+		case free < 0:
+			free = idx
+		}
+		idx = (idx + count) & mask
+	}
+
+	if free >= 0 {
+		return free, false
+	}
+	return idx, false
+}
+
+// rehash rehashes all existing elements into a new table, dropping the
+// tombstones, and grows the table if it is too full for another element.
+func (m *FuncMap[K, V]) rehash() {
+	old := m.table
+	if (m.size+1)*maximumLoad >= m.capacity {
+		m.capacity *= 2
+	}
+	m.table = make([]funcEntry[K, V], m.capacity)
+	m.tombstones = 0
+
+	for _, entry := range old {
+		if entry.used {
+			idx, _ := m.find(entry.key, entry.hash)
+			m.table[idx] = entry
+		}
+	}
+}
+
+// Set inserts or updates a key-value pair.
+// If the key exists, only the value is updated.
+// If the key is new, both key and value are inserted.
+func (m *FuncMap[K, V]) Set(key K, value V) {
+	if (m.size+m.tombstones+1)*maximumLoad >= m.capacity {
+		m.rehash()
+	}
+
+	hash := m.hash(key)
+	idx, found := m.find(key, hash)
+	if found {
+		m.table[idx].value = value
+		return
+	}
+
+	if m.table[idx].deleted {
+		m.tombstones--
+	}
+	m.table[idx] = funcEntry[K, V]{key: key, value: value, hash: hash, used: true}
+	m.size++
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found, zero value and false otherwise.
+func (m *FuncMap[K, V]) Get(key K) (V, bool) {
+	idx, found := m.find(key, m.hash(key))
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return m.table[idx].value, true
+}
+
+// Contains checks whether a key exists in the map.
+func (m *FuncMap[K, V]) Contains(key K) bool {
+	_, found := m.find(key, m.hash(key))
+	return found
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (m *FuncMap[K, V]) Delete(key K) bool {
+	idx, found := m.find(key, m.hash(key))
+	if !found {
+		return false
+	}
+
+	m.table[idx] = funcEntry[K, V]{deleted: true}
+	m.size--
+	m.tombstones++
+	return true
+}
+
+// Clear removes all elements from the map.
+func (m *FuncMap[K, V]) Clear() {
+	m.table = make([]funcEntry[K, V], initialCapacity)
+	m.capacity = initialCapacity
+	m.size, m.tombstones = 0, 0
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *FuncMap[K, V]) Size() int {
+	return m.size
+}
+
+// Capacity returns the current capacity of the underlying table.
+func (m *FuncMap[K, V]) Capacity() int {
+	return m.capacity
+}
+
+// Iter returns an iterator over key-value pairs.
+func (m *FuncMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range m.table {
+			if entry := &m.table[i]; entry.used {
+				if !yield(entry.key, entry.value) {
+					return
+				}
+			}
+		}
+	}
+}
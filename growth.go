@@ -0,0 +1,130 @@
+package hashmap
+
+// GrowthPolicy controls how a HashMap's table grows.
+// With the default factor of 2 and a power-of-two minimum capacity, tables
+// keep power-of-two sizes and index by masking. Any other configuration
+// uses prime table sizes indexed by modulo, which keeps quadratic probing
+// able to reach a free slot at the 50% load factor.
+type GrowthPolicy struct {
+	Factor      float64 // Capacity multiplier on growth, greater than 1; 2 when zero
+	MinCapacity int     // Initial capacity, also restored by Clear; 8 when zero
+	MaxCapacity int     // Capacity beyond which the table does not grow, a power of two unless sizes are prime; unlimited when zero
+	ShrinkLoad  float64 // Load below which deletions shrink the table, below 0.25; never when zero
+}
+
+// NewWithGrowthPolicy creates a new HashMap whose table grows according
+// to p. Once the table reaches p.MaxCapacity, inserting a new key beyond
+// the maximum load panics. It panics if p is invalid.
+func NewWithGrowthPolicy[K comparable, V any](p GrowthPolicy) *HashMap[K, V] {
+	return New[K, V](WithGrowthPolicy(p))
+}
+
+// ShrinkToFit rehashes the table into the smallest capacity the growth
+// policy allows for the current elements, releasing the memory of a map
+// that has shrunk for good. It does nothing if the table is already that
+// small.
+func (h *HashMap[K, V]) ShrinkToFit() {
+	if h.deferred {
+		return
+	}
+	if capacity := h.sizedCapacity(h.size); capacity < h.capacity {
+		h.resize(capacity)
+	}
+}
+
+// shrink rehashes the table into a smaller one after deletions once the
+// load drops below the policy's ShrinkLoad. The new table is sized for
+// twice the elements, so that the next insertions do not grow it again
+// right away.
+func (h *HashMap[K, V]) shrink() {
+	if h.growth == nil || float64(h.size) >= h.growth.ShrinkLoad*float64(h.capacity) {
+		return
+	}
+	if capacity := h.sizedCapacity(2 * h.size); capacity < h.capacity {
+		h.resize(capacity)
+	}
+}
+
+// minimumCapacity returns the capacity of a freshly created or cleared table.
+func (h *HashMap[K, V]) minimumCapacity() int {
+	if h.growth == nil {
+		return initialCapacity
+	}
+	if h.prime {
+		return nextPrime(h.growth.MinCapacity)
+	}
+	return h.growth.MinCapacity
+}
+
+// grownCapacity returns the capacity the table grows to next.
+// Returns the current capacity if the table cannot grow any further.
+func (h *HashMap[K, V]) grownCapacity() int {
+	return h.growFrom(h.capacity)
+}
+
+// sizedCapacity returns the capacity a table reaches by growing from its
+// minimum until it holds n elements, or as close as the policy allows.
+func (h *HashMap[K, V]) sizedCapacity(n int) int {
+	capacity := h.minimumCapacity()
+	for !h.fits(n, capacity) && h.growFrom(capacity) > capacity {
+		capacity = h.growFrom(capacity)
+	}
+	return capacity
+}
+
+// growFrom returns the capacity a table of the given capacity grows to.
+func (h *HashMap[K, V]) growFrom(current int) int {
+	if h.growth == nil {
+		return current * 2
+	}
+
+	capacity := max(int(float64(current)*h.growth.Factor), current+1)
+	if h.prime {
+		capacity = nextPrime(capacity)
+	}
+
+	if limit := h.growth.MaxCapacity; limit != 0 && capacity > limit {
+		capacity = limit
+		if h.prime {
+			capacity = prevPrime(limit)
+		}
+	}
+	return max(capacity, current)
+}
+
+// wrap maps a probe position onto a table of the given capacity.
+func (h *HashMap[K, V]) wrap(idx, capacity int) int {
+	if h.prime {
+		return idx % capacity
+	}
+	return idx & (capacity - 1)
+}
+
+// isPrime reports whether n is prime.
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// nextPrime returns the smallest prime not below n.
+func nextPrime(n int) int {
+	for !isPrime(n) {
+		n++
+	}
+	return n
+}
+
+// prevPrime returns the largest prime not above n, or 2 if there is none.
+func prevPrime(n int) int {
+	for n > 2 && !isPrime(n) {
+		n--
+	}
+	return max(n, 2)
+}
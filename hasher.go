@@ -0,0 +1,25 @@
+package hashmap
+
+import "github.com/nukilabs/hashmap/rapidhash"
+
+// WithHasher hashes keys with fn instead of the built-in hashing, for
+// domain-specific keys such as structs identified by one field or
+// normalized URLs. Keys that are equal must have equal hashes; since
+// string keys are compared case-insensitively unless WithCaseSensitive
+// is given, fn must then fold case as well. The result of fn is mixed
+// with the map's seed, so RotateSeed and two-choice insertion still
+// work. New panics if fn does not take the map's key type.
+func WithHasher[K comparable](fn func(K) uint64) Option {
+	return func(c *Config) { c.Hasher = fn }
+}
+
+// NewWithHasher creates a new HashMap that hashes keys with fn, see
+// WithHasher.
+func NewWithHasher[K comparable, V any](fn func(K) uint64) *HashMap[K, V] {
+	return New[K, V](WithHasher(fn))
+}
+
+// hashCustom hashes a key with the map's hasher under seed.
+func (h *HashMap[K, V]) hashCustom(key K, seed uint64) uint32 {
+	return uint32(rapidhash.Sum64Uint64(h.hasher(key), seed))
+}
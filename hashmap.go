@@ -1,16 +1,26 @@
 // Package hashmap provides a hash table implementation with case-insensitive
-// string hashing, matching Chromium's WTF HashMap behavior.
+// string keys, matching Chromium's WTF HashMap behavior.
 package hashmap
 
 import (
 	"iter"
+	"math/rand/v2"
+	"slices"
+	"time"
 
+	"github.com/nukilabs/hashmap/bloom"
+	"github.com/nukilabs/hashmap/rapidhash"
 	"github.com/nukilabs/hashmap/traits"
 )
 
 const (
 	initialCapacity = 8
-	maximumLoad     = 2 // Expands at 50% load factor
+	maximumLoad     = 2                 // Expands at 50% load factor
+	defaultMaxLoad  = 1.0 / maximumLoad // Load factor at which tables grow by default
+	maxMaxLoad      = 0.9               // Highest load factor WithMaxLoad accepts
+
+	bloomFalsePositiveRate = 0.01
+	secondarySeed          = 0x9e3779b97f4a7c15 // Seed for a second, independent string hash
 )
 
 // Pair represents a key-value pair stored in the hash table.
@@ -19,134 +29,637 @@ type Pair[K comparable, V any] struct {
 	Value V
 }
 
+// slot is an entry of the hash table, stored inline in the table or
+// behind a pointer depending on the size of V, see slotTable. Deleting an
+// entry leaves a tombstone: the slot is free for a new entry, but probe
+// sequences continue past it, so entries placed further along the same
+// chains stay reachable.
+type slot[K comparable, V any] struct {
+	Pair[K, V]
+	used    bool
+	deleted bool // Whether the slot is a tombstone
+}
+
 // HashMap is a hash table using quadratic probing for collision resolution
-// and case-insensitive hashing for string keys.
+// by default, see WithProbing. String keys are hashed and compared
+// case-insensitively, like with Chromium's CaseFoldingHashTraits, so keys
+// differing only in case are the same key.
 type HashMap[K comparable, V any] struct {
-	table    []*Pair[K, V]
-	size     int
-	capacity int
+	table         slotTable[K, V]
+	size          int
+	capacity      int
+	tombstones    int                   // Number of tombstones in table
+	filter        *bloom.Filter         // Optional filter short-circuiting lookup misses
+	twoChoice     bool                  // Whether keys may live on either of two probe chains
+	journal       Journal[K, V]         // Optional sink for applied mutations
+	interner      *Interner             // Optional deduplicator for inserted string keys
+	growth        *GrowthPolicy         // Optional growth policy; tables double when nil
+	prime         bool                  // Whether the capacity is prime rather than a power of two
+	seed          uint64                // Seed for hashing string keys
+	old           *slotTable[K, V]      // Table being migrated away from after RotateSeed
+	oldSeed       uint64                // Seed the old table was hashed with
+	migrated      int                   // Number of slots of old already migrated
+	caseSensitive bool                  // Whether string keys hash and compare by their exact bytes
+	deferred      bool                  // Whether entries are collected in pending until Build
+	pending       []Pair[K, V]          // Entries set before Build, in insertion order
+	times         *HashMap[K, Metadata] // Optional per-entry timestamps
+	validation    KeyValidation         // How Set handles invalid header field names
+	keyErr        error                 // First key ignored in ValidateError mode
+	tracing       *Tracing              // Optional trace instrumentation
+	probing       Probing               // Probe sequence resolving collisions
+	canon         func(K) K             // Optional transform applied to keys passed in
+	hasher        func(K) uint64        // Optional hash function replacing the built-in one
+	randomOrder   bool                  // Whether iteration starts at a random slot
+	failFast      bool                  // Whether iteration panics after structural modifications
+	mods          uint64                // Number of structural modifications
+	maxLoad       float64               // Load factor at which the table grows
+	incremental   bool                  // Whether rehashing migrates entries incrementally
 }
 
-// New creates a new HashMap with the default initial capacity.
-func New[K comparable, V any]() *HashMap[K, V] {
-	return &HashMap[K, V]{
-		table:    make([]*Pair[K, V], initialCapacity),
-		capacity: initialCapacity,
+// New creates a new HashMap configured by opts. Without options the map
+// starts at the default initial capacity. It panics if the resulting
+// Config is invalid.
+func New[K comparable, V any](opts ...Option) *HashMap[K, V] {
+	var c Config
+	for _, opt := range opts {
+		opt(&c)
 	}
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+	return newFromConfig[K, V](c)
+}
+
+// NewWithBloomFilter creates a new HashMap that maintains a Bloom filter
+// over its keys, so that lookups of absent keys usually return without
+// probing the table. This pays off when most lookups are misses.
+// Deleted keys stay in the filter until the next rehash.
+func NewWithBloomFilter[K comparable, V any]() *HashMap[K, V] {
+	return New[K, V](WithBloomFilter())
+}
+
+// NewWithInterner creates a new HashMap that copies newly inserted string
+// keys into in, reusing the existing copy when the same key, ignoring
+// case unless the map is case-sensitive, was inserted before by any map
+// sharing in. Keys of other types are stored as is.
+func NewWithInterner[K comparable, V any](in *Interner) *HashMap[K, V] {
+	return New[K, V](WithInterner(in))
+}
+
+// NewTwoChoice creates a new HashMap using "power of two choices"
+// insertion: every key has two home buckets derived from independently
+// seeded hashes, and new keys go to whichever probe chain reaches a free
+// slot sooner. This flattens the tail of probe lengths for clumpy key
+// sets at the cost of probing both chains on a miss.
+func NewTwoChoice[K comparable, V any]() *HashMap[K, V] {
+	return New[K, V](WithTwoChoice())
+}
+
+// NewWithCapacity creates a new HashMap sized to hold n elements without
+// rehashing, so that bulk loads of a known size allocate the table once.
+func NewWithCapacity[K comparable, V any](n int) *HashMap[K, V] {
+	return New[K, V](WithCapacity(n))
+}
+
+// newFilter creates a Bloom filter sized for the elements the table can
+// hold before growing.
+func (h *HashMap[K, V]) newFilter() *bloom.Filter {
+	return bloom.New(int(float64(h.capacity)*h.maxLoad), bloomFalsePositiveRate)
+}
+
+// fits reports whether a table of the given capacity holds n elements
+// below the maximum load.
+func (h *HashMap[K, V]) fits(n, capacity int) bool {
+	return float64(n) < h.maxLoad*float64(capacity)
 }
 
 // hash computes the hash value for a key.
 // For strings, uses case-insensitive hashing.
 func (h *HashMap[K, V]) hash(key K) uint32 {
+	return h.hashWith(key, h.seed)
+}
+
+// hashWith computes the hash value for a key using the given seed.
+func (h *HashMap[K, V]) hashWith(key K, seed uint64) uint32 {
+	if f := activeFaults(); f != nil && f.Collide {
+		return 0
+	}
+	if h.hasher != nil {
+		return h.hashCustom(key, seed)
+	}
+
 	switch k := any(key).(type) {
 	case string:
-		return traits.CaseFoldingHash(k)
+		if h.caseSensitive {
+			return uint32(rapidhash.Sum64String(k, seed))
+		}
+		return traits.CaseFoldingHashWithSeed(k, seed)
 	default:
-		return 0
+		return hashComparable(key, seed)
+	}
+}
+
+// equal reports whether two keys are the same key.
+func (h *HashMap[K, V]) equal(a, b K) bool {
+	if h.caseSensitive {
+		return a == b
+	}
+	return equalKeys(a, b)
+}
+
+// equalKeys reports whether two keys are the same key, comparing strings
+// case-insensitively with the folding used for hashing.
+func equalKeys[K comparable](a, b K) bool {
+	if a == b {
+		return true
+	}
+	s, ok := any(a).(string)
+	t, _ := any(b).(string)
+	return ok && traits.EqualFold(s, t)
+}
+
+// keyIdentity returns the options creating maps that tell keys apart
+// like h, for internal maps keyed by its keys.
+func (h *HashMap[K, V]) keyIdentity() []Option {
+	if h.caseSensitive {
+		return []Option{WithCaseSensitive()}
 	}
+	return nil
 }
 
-// index returns the bucket index for a hash value.
-func (h *HashMap[K, V]) index(hash uint32) int {
-	return int(hash & uint32(h.capacity-1))
+// foldedKey returns the spelling of key shared by every key h treats as
+// equal to it: its canonical form, case-folded unless h is
+// case-sensitive. It lets code outside the map, such as a Store, tell
+// keys apart the way h does.
+func (h *HashMap[K, V]) foldedKey(key K) K {
+	key = h.canonical(key)
+	if h.caseSensitive {
+		return key
+	}
+	if s, ok := any(key).(string); ok {
+		return any(foldKey(s)).(K)
+	}
+	return key
+}
+
+// altHash computes the second hash value for a key in two-choice mode,
+// for a table hashed with the given seed.
+func (h *HashMap[K, V]) altHash(key K, seed uint64) uint32 {
+	return h.hashWith(key, seed^secondarySeed)
+}
+
+// intern returns the interned copy of a string key, or the key unchanged.
+// Case-sensitive maps get a copy spelled exactly like the key.
+func (h *HashMap[K, V]) intern(key K) K {
+	if s, ok := any(key).(string); ok {
+		return any(h.interner.intern(s, h.caseSensitive)).(K)
+	}
+	return key
+}
+
+// index returns the bucket index for a hash value in a table of the
+// given capacity.
+func (h *HashMap[K, V]) index(hash uint32, capacity int) int {
+	if h.prime {
+		return int(hash % uint32(capacity))
+	}
+	return int(hash & uint32(capacity-1))
 }
 
-// find locates the slot for a key using quadratic probing.
+// find locates the slot for a key with the given hash.
 // Returns the index and whether the key was found.
-func (h *HashMap[K, V]) find(key K) (int, bool) {
-	hash := h.hash(key)
-	idx := h.index(hash)
+func (h *HashMap[K, V]) find(key K, hash uint32) (int, bool) {
+	return h.findIn(&h.table, h.seed, key, hash)
+}
+
+// findIn locates the slot for a key in a table hashed with seed.
+// In two-choice mode both probe chains are searched, and a missing key
+// is assigned the slot on the shorter chain.
+func (h *HashMap[K, V]) findIn(table *slotTable[K, V], seed uint64, key K, hash uint32) (int, bool) {
+	idx, found, count := h.probe(table, key, hash)
+	if h.tracing != nil {
+		h.tracing.probe(h.size, table.len(), count)
+	}
+	if found || !h.twoChoice {
+		return idx, found
+	}
+
+	alt, found, altCount := h.probe(table, key, h.altHash(key, seed))
+	if h.tracing != nil {
+		h.tracing.probe(h.size, table.len(), altCount)
+	}
+	if found || altCount < count {
+		return alt, found
+	}
+	return idx, false
+}
+
+// probe walks the probe sequence starting at the bucket for hash, past
+// tombstones, until it finds the key or an empty slot. For a missing key
+// the first tombstone passed is returned, so that it gets reused.
+// Returns the index, whether the key was found, and the number of probes taken.
+func (h *HashMap[K, V]) probe(table *slotTable[K, V], key K, hash uint32) (int, bool, int) {
+	capacity := table.len()
+	idx := h.index(hash, capacity)
 	count := 0
+	step := 0
+	if h.probing == ProbeDouble {
+		step = h.doubleStep(hash, capacity)
+	}
+	free := -1
 
 	for {
-		if h.table[idx] == nil {
-			return idx, false
-		}
-
-		if h.table[idx].Key == key {
-			return idx, true
+		s := table.at(idx)
+		switch {
+		case s.used:
+			if h.equal(s.Key, key) {
+				return idx, true, count
+			}
+		case !s.deleted:
+			if free >= 0 {
+				return free, false, count
+			}
+			return idx, false, count
+		case free < 0:
+			free = idx
 		}
 
 		count++
-		if count >= h.capacity {
+		if count >= capacity {
 			break
 		}
-		idx = (idx + count) & (h.capacity - 1)
+		switch h.probing {
+		case ProbeLinear:
+			idx = h.wrap(idx+1, capacity)
+		case ProbeDouble:
+			idx = h.wrap(idx+step, capacity)
+		default:
+			idx = h.wrap(idx+count, capacity)
+		}
 	}
 
-	return idx, false
+	if free >= 0 {
+		return free, false, count
+	}
+	return idx, false, count
+}
+
+// clone returns a copy of h whose tables can be changed independently.
+// The journal and interner are sinks and stay shared.
+func (h *HashMap[K, V]) clone() *HashMap[K, V] {
+	c := *h
+	c.table = h.table.clone()
+	if h.old != nil {
+		old := h.old.clone()
+		c.old = &old
+	}
+	c.pending = slices.Clone(h.pending)
+	if h.filter != nil {
+		c.filter = h.filter.Clone()
+	}
+	if h.times != nil {
+		c.times = h.times.clone()
+	}
+	return &c
+}
+
+// cloneEmpty returns an empty map configured like h, whose table can
+// hold n elements without rehashing. It keeps the seed, growth policy,
+// probing, key handling and the other options of h, and gets its own
+// Bloom filter and timestamps if h has them. The journal is not carried
+// over, since it records the mutations of h alone.
+func (h *HashMap[K, V]) cloneEmpty(n int) *HashMap[K, V] {
+	c := *h
+	c.size, c.tombstones, c.mods = 0, 0, 0
+	c.old, c.migrated = nil, 0
+	c.journal, c.keyErr = nil, nil
+	c.deferred, c.pending = false, nil
+	c.capacity = h.sizedCapacity(n)
+	c.table = newSlotTable[K, V](c.capacity)
+	if h.filter != nil {
+		c.filter = c.newFilter()
+	}
+	if h.times != nil {
+		c.times = New[K, Metadata](h.keyIdentity()...)
+	}
+	return &c
+}
+
+// lookup locates an existing key, returning its slot or nil. The Bloom
+// filter is consulted first when enabled, so that most misses skip
+// probing entirely. While migrating after RotateSeed, keys not found in
+// the table are looked up in the old table, and the filter is bypassed
+// since it only covers migrated entries.
+func (h *HashMap[K, V]) lookup(key K) *slot[K, V] {
+	s, _ := h.locate(key)
+	return s
+}
+
+// locate is like lookup, but also reports whether the slot is in the old
+// table of a migration rather than in the current table.
+func (h *HashMap[K, V]) locate(key K) (*slot[K, V], bool) {
+	if h.deferred {
+		panic("hashmap: map read before Build")
+	}
+
+	hash := h.hash(key)
+	if h.old != nil {
+		if idx, found := h.find(key, hash); found {
+			return h.table.at(idx), false
+		}
+		s := h.lookupOld(key)
+		return s, s != nil
+	}
+
+	if h.filter != nil && !h.filter.ContainsHash(uint64(hash)) {
+		return nil, false
+	}
+	if idx, found := h.find(key, hash); found {
+		return h.table.at(idx), false
+	}
+	return nil, false
+}
+
+// slots returns an iterator over the occupied slots, in table order from
+// a random start with WithRandomIteration. A migration pending after
+// RotateSeed is completed first, since iteration visits every slot
+// anyway. If the table is replaced during iteration, the remaining
+// entries of the table iteration started with are looked up in the new
+// one, so that no entry is skipped or yielded twice.
+func (h *HashMap[K, V]) slots() iter.Seq[*slot[K, V]] {
+	return func(yield func(*slot[K, V]) bool) {
+		if h.deferred {
+			panic("hashmap: map read before Build")
+		}
+		h.migrate(h.old.len())
+
+		mods := h.mods
+		table := h.table
+		start := 0
+		if h.randomOrder {
+			start = rand.IntN(table.len())
+		}
+		for n := range table.len() {
+			s := table.at((start + n) % table.len())
+			if !s.used {
+				continue
+			}
+
+			if !h.table.same(&table) {
+				if s = h.lookup(s.Key); s == nil {
+					continue
+				}
+			}
+			if !yield(s) {
+				return
+			}
+			if h.failFast && h.mods != mods {
+				panic("hashmap: map modified during iteration")
+			}
+		}
+	}
 }
 
-// rehash grows the table and rehashes all existing elements.
+// rehash makes room for another element: it grows the table if it is
+// too full, and otherwise rehashes it at the same capacity to clear its
+// tombstones. With WithIncrementalRehash the entries are migrated to the
+// new table over the following mutations instead of all at once.
 func (h *HashMap[K, V]) rehash() {
+	capacity := h.capacity
+	if !h.fits(h.size+1, h.capacity) && h.grownCapacity() > h.capacity {
+		capacity = h.grownCapacity()
+	} else if h.tombstones == 0 {
+		return
+	}
+
+	if !h.incremental {
+		h.resize(capacity)
+		return
+	}
+	injectAllocFailure(capacity)
+	if h.tracing != nil {
+		defer h.tracing.rehash(h.size, h.capacity, capacity)()
+	}
+	h.migrateTo(capacity, h.seed)
+}
+
+// resize replaces the table with one of the given capacity and reinserts
+// all existing elements.
+func (h *HashMap[K, V]) resize(capacity int) {
+	injectAllocFailure(capacity)
+	h.migrate(h.old.len())
+	if h.tracing != nil {
+		defer h.tracing.rehash(h.size, h.capacity, capacity)()
+	}
+
 	old := h.table
-	h.capacity *= 2
-	h.table = make([]*Pair[K, V], h.capacity)
-	h.size = 0
+	h.capacity = capacity
+	h.table = newSlotTable[K, V](h.capacity)
+	h.size, h.tombstones = 0, 0
+	if h.filter != nil {
+		h.filter = h.newFilter()
+	}
 
-	for _, pair := range old {
-		if pair != nil {
-			h.Set(pair.Key, pair.Value)
+	for i := range old.len() {
+		if s := old.at(i); s.used {
+			h.set(s.Key, s.Value)
 		}
 	}
 }
 
 // Set inserts or updates a key-value pair.
-// If the key exists, only the value is updated.
+// If the key exists, only the value is updated, and the key keeps the
+// spelling it was first inserted with.
 // If the key is new, both key and value are inserted.
 func (h *HashMap[K, V]) Set(key K, value V) {
-	if (h.size+1)*maximumLoad >= h.capacity {
+	key = h.canonical(key)
+	if h.rejected(key) {
+		return
+	}
+
+	h.injectRehash()
+	key = h.set(key, value)
+	h.recordSet(key, value)
+}
+
+// rejected reports whether a key fails validation and must not be
+// inserted, panicking or recording the error as configured.
+func (h *HashMap[K, V]) rejected(key K) bool {
+	err := h.checkKey(key)
+	if err == nil {
+		return false
+	}
+	if h.validation == ValidatePanic {
+		panic(err)
+	}
+	if h.keyErr == nil {
+		h.keyErr = err
+	}
+	return true
+}
+
+// recordSet stamps and journals a key set to value.
+func (h *HashMap[K, V]) recordSet(key K, value V) {
+	if h.times != nil {
+		h.stamp(key, time.Now())
+	}
+	if h.journal != nil {
+		h.journal.Append(Record[K, V]{Op: OpSet, Key: key, Value: value})
+	}
+}
+
+// set inserts or updates a key-value pair without journaling.
+// Returns the key as stored in the map.
+func (h *HashMap[K, V]) set(key K, value V) K {
+	if h.deferred {
+		h.pending = append(h.pending, Pair[K, V]{Key: key, Value: value})
+		return key
+	}
+	if h.old != nil {
+		h.migrate(migrationStep)
+	}
+
+	if !h.fits(h.size+h.tombstones+1, h.capacity) {
 		h.rehash()
 	}
 
-	idx, found := h.find(key)
+	hash := h.hash(key)
+	idx, found := h.find(key, hash)
 	if found {
-		h.table[idx].Value = value
-		return
+		s := h.table.at(idx)
+		s.Value = value
+		return s.Key
+	}
+	if h.old != nil {
+		if s := h.lookupOld(key); s != nil {
+			s.Value = value
+			return s.Key
+		}
+	}
+
+	if !h.fits(h.size+1, h.capacity) {
+		panic("hashmap: maximum capacity exceeded")
 	}
 
-	h.table[idx] = &Pair[K, V]{
-		Key:   key,
-		Value: value,
+	if h.interner != nil {
+		key = h.intern(key)
 	}
+	h.place(idx, slot[K, V]{
+		Pair: Pair[K, V]{Key: key, Value: value},
+		used: true,
+	})
 	h.size++
+	if h.filter != nil {
+		h.filter.AddHash(uint64(hash))
+	}
+	return key
+}
+
+// place stores s in the free slot at idx, which may be a tombstone.
+func (h *HashMap[K, V]) place(idx int, s slot[K, V]) {
+	h.mods++
+	if h.table.at(idx).deleted {
+		h.tombstones--
+	}
+	h.table.set(idx, s)
 }
 
 // Get retrieves the value for a key.
 // Returns the value and true if found, zero value and false otherwise.
 func (h *HashMap[K, V]) Get(key K) (V, bool) {
-	idx, found := h.find(key)
-	if !found {
+	key = h.canonical(key)
+	s := h.lookup(key)
+	if s == nil {
 		var zero V
 		return zero, false
 	}
-	return h.table[idx].Value, true
+	if h.times != nil {
+		if meta, found := h.times.Get(key); found {
+			meta.Accessed = time.Now()
+			h.times.Set(key, meta)
+		}
+	}
+	return s.Value, true
 }
 
 // Contains checks whether a key exists in the map.
 func (h *HashMap[K, V]) Contains(key K) bool {
-	_, found := h.find(key)
-	return found
+	return h.lookup(h.canonical(key)) != nil
 }
 
 // Delete removes a key-value pair from the map.
 // Returns true if the key was found and deleted.
 func (h *HashMap[K, V]) Delete(key K) bool {
-	idx, found := h.find(key)
-	if !found {
+	key = h.canonical(key)
+	if h.old != nil {
+		h.migrate(migrationStep)
+	}
+
+	s, old := h.locate(key)
+	if s == nil {
 		return false
 	}
+	h.remove(s, old)
+	h.shrink()
+	return true
+}
 
-	h.table[idx] = nil
+// remove turns the occupied slot s into a tombstone, where old reports
+// whether s is in the old table of a migration.
+func (h *HashMap[K, V]) remove(s *slot[K, V], old bool) {
+	h.mods++
+	key := s.Key
+	*s = slot[K, V]{deleted: true}
 	h.size--
-	return true
+	if !old {
+		h.tombstones++
+	}
+	if h.times != nil {
+		h.times.Delete(key)
+	}
+	if h.journal != nil {
+		h.journal.Append(Record[K, V]{Op: OpDelete, Key: key})
+	}
 }
 
-// Clear removes all elements from the map.
+// Clear removes all elements from the map and shrinks the table back to
+// its initial capacity. Use Reset to keep the table for refilling.
 func (h *HashMap[K, V]) Clear() {
-	h.table = make([]*Pair[K, V], initialCapacity)
-	h.capacity = initialCapacity
-	h.size = 0
+	h.mods++
+	h.capacity = h.minimumCapacity()
+	h.table = newSlotTable[K, V](h.capacity)
+	h.old, h.migrated = nil, 0
+	h.pending = nil
+	h.size, h.tombstones = 0, 0
+	if h.times != nil {
+		h.times.Clear()
+	}
+	if h.filter != nil {
+		h.filter = h.newFilter()
+	}
+	if h.journal != nil {
+		h.journal.Append(Record[K, V]{Op: OpClear})
+	}
+}
+
+// Reset removes all elements from the map but keeps the allocated table,
+// so a map that is refilled to a similar size does not grow again.
+// Use Clear to release the memory instead.
+func (h *HashMap[K, V]) Reset() {
+	h.mods++
+	h.table.clear()
+	h.old, h.migrated = nil, 0
+	h.pending = h.pending[:0]
+	h.size, h.tombstones = 0, 0
+	if h.times != nil {
+		h.times.Reset()
+	}
+	if h.filter != nil {
+		h.filter.Reset()
+	}
+	if h.journal != nil {
+		h.journal.Append(Record[K, V]{Op: OpClear})
+	}
 }
 
 // Size returns the number of key-value pairs in the map.
@@ -159,14 +672,62 @@ func (h *HashMap[K, V]) Capacity() int {
 	return h.capacity
 }
 
+// Reserve grows the table to hold n elements in total without further
+// rehashing, as far as the growth policy allows, so that a bulk load
+// rehashes at most once. It never shrinks the table. In deferred mode it
+// reserves room for n pending entries instead. It panics if n is
+// negative.
+func (h *HashMap[K, V]) Reserve(n int) {
+	if n < 0 {
+		panic("hashmap: capacity must not be negative")
+	}
+	if h.deferred {
+		if n > len(h.pending) {
+			h.pending = slices.Grow(h.pending, n-len(h.pending))
+		}
+		return
+	}
+	if capacity := h.sizedCapacity(n); capacity > h.capacity {
+		h.resize(capacity)
+	}
+}
+
 // Iter returns an iterator over key-value pairs.
+// The map may be modified during iteration, even if that grows the
+// table. Entries deleted before they are reached are not yielded, and
+// updated entries are yielded with their new value. Entries inserted
+// during iteration may or may not be yielded. No entry is yielded twice.
+// In particular, deleting the entry just yielded neither skips nor
+// repeats any other; DeleteFunc deletes matching entries in one call.
 func (h *HashMap[K, V]) Iter() iter.Seq2[K, V] {
 	return func(yield func(K, V) bool) {
-		for _, pair := range h.table {
-			if pair != nil {
-				if !yield(pair.Key, pair.Value) {
-					return
-				}
+		for s := range h.slots() {
+			if !yield(s.Key, s.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys, in the spelling they were first
+// inserted with. It behaves like Iter under modification.
+func (h *HashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for s := range h.slots() {
+			if !yield(s.Key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values. It behaves like Iter under
+// modification.
+func (h *HashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for s := range h.slots() {
+			if !yield(s.Value) {
+				return
 			}
 		}
 	}
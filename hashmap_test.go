@@ -0,0 +1,361 @@
+package hashmap
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// collide hashes every key to the same value, so that all keys share one
+// probe chain.
+func collide(string) uint64 { return 0 }
+
+// checkContents checks that h holds exactly want, through both iteration
+// and lookups.
+func checkContents(t *testing.T, h *HashMap[string, int], want map[string]int) {
+	t.Helper()
+	if got := h.ToMap(); !maps.Equal(got, want) {
+		t.Fatalf("map holds %v, want %v", got, want)
+	}
+	if h.Size() != len(want) {
+		t.Fatalf("Size = %d, want %d", h.Size(), len(want))
+	}
+	for key, value := range want {
+		if got, found := h.Get(key); !found || got != value {
+			t.Fatalf("Get(%q) = %d, %v, want %d, true", key, got, found, value)
+		}
+	}
+}
+
+func TestDeleteReinsert(t *testing.T) {
+	tests := []struct {
+		name       string
+		ops        func(h *HashMap[string, int])
+		want       map[string]int
+		tombstones int
+	}{
+		{
+			name: "reinsert deleted key",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("a", 1)
+				h.Set("b", 2)
+				h.Set("c", 3)
+				h.Delete("b")
+				h.Set("b", 4)
+			},
+			want: map[string]int{"a": 1, "b": 4, "c": 3},
+		},
+		{
+			name: "delete head of chain",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("a", 1)
+				h.Set("b", 2)
+				h.Set("c", 3)
+				h.Delete("a")
+			},
+			want:       map[string]int{"b": 2, "c": 3},
+			tombstones: 1,
+		},
+		{
+			name: "new key reuses tombstone",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("a", 1)
+				h.Set("b", 2)
+				h.Set("c", 3)
+				h.Delete("a")
+				h.Set("d", 4)
+			},
+			want: map[string]int{"b": 2, "c": 3, "d": 4},
+		},
+		{
+			name: "existing key past tombstone is updated in place",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("a", 1)
+				h.Set("b", 2)
+				h.Delete("a")
+				h.Set("b", 3)
+			},
+			want:       map[string]int{"b": 3},
+			tombstones: 1,
+		},
+		{
+			name: "delete everything and refill",
+			ops: func(h *HashMap[string, int]) {
+				for _, key := range []string{"a", "b", "c"} {
+					h.Set(key, 1)
+				}
+				for _, key := range []string{"c", "a", "b"} {
+					h.Delete(key)
+				}
+				h.Set("c", 2)
+				h.Set("a", 3)
+			},
+			want:       map[string]int{"a": 3, "c": 2},
+			tombstones: 1,
+		},
+		{
+			name: "delete missing key",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("a", 1)
+				h.Delete("a")
+				if h.Delete("a") {
+					panic("deleted a twice")
+				}
+			},
+			want:       map[string]int{},
+			tombstones: 1,
+		},
+	}
+
+	probings := map[string]Probing{"quadratic": ProbeQuadratic, "linear": ProbeLinear, "double": ProbeDouble}
+	for name, probing := range probings {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					h := New[string, int](WithHasher(collide), WithProbing(probing), WithCapacity(16))
+					tt.ops(h)
+					checkContents(t, h, tt.want)
+					if h.tombstones != tt.tombstones {
+						t.Fatalf("%d tombstones, want %d", h.tombstones, tt.tombstones)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestCaseVariantKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		caseSensitive bool
+		ops           func(h *HashMap[string, int])
+		want          map[string]int
+	}{
+		{
+			name: "variants update the first spelling",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("Content-Type", 1)
+				h.Set("content-type", 2)
+				h.Set("CONTENT-TYPE", 3)
+			},
+			want: map[string]int{"Content-Type": 3},
+		},
+		{
+			name:          "variants are distinct when case-sensitive",
+			caseSensitive: true,
+			ops: func(h *HashMap[string, int]) {
+				h.Set("Content-Type", 1)
+				h.Set("content-type", 2)
+				h.Set("CONTENT-TYPE", 3)
+			},
+			want: map[string]int{"Content-Type": 1, "content-type": 2, "CONTENT-TYPE": 3},
+		},
+		{
+			name: "delete through a variant",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("Accept", 1)
+				h.Set("Host", 2)
+				h.Delete("ACCEPT")
+			},
+			want: map[string]int{"Host": 2},
+		},
+		{
+			name:          "delete through a variant when case-sensitive",
+			caseSensitive: true,
+			ops: func(h *HashMap[string, int]) {
+				h.Set("Accept", 1)
+				h.Set("Host", 2)
+				h.Delete("ACCEPT")
+			},
+			want: map[string]int{"Accept": 1, "Host": 2},
+		},
+		{
+			name: "reinsert after delete takes the new spelling",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("Accept", 1)
+				h.Delete("accept")
+				h.Set("ACCEPT", 2)
+			},
+			want: map[string]int{"ACCEPT": 2},
+		},
+		{
+			name: "Latin-1 letters fold",
+			ops: func(h *HashMap[string, int]) {
+				h.Set("\xc9T\xc9", 1)
+				h.Set("\xe9t\xe9", 2)
+			},
+			want: map[string]int{"\xc9T\xc9": 2},
+		},
+		{
+			name:          "Latin-1 letters are distinct when case-sensitive",
+			caseSensitive: true,
+			ops: func(h *HashMap[string, int]) {
+				h.Set("\xc9T\xc9", 1)
+				h.Set("\xe9t\xe9", 2)
+			},
+			want: map[string]int{"\xc9T\xc9": 1, "\xe9t\xe9": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.caseSensitive {
+				opts = append(opts, WithCaseSensitive())
+			}
+			h := New[string, int](opts...)
+			tt.ops(h)
+			checkContents(t, h, tt.want)
+		})
+	}
+}
+
+// migratingMap returns a map with WithIncrementalRehash that has just
+// started migrating to a grown table, and the entries it holds.
+func migratingMap(t *testing.T) (*HashMap[string, int], map[string]int) {
+	h := New[string, int](WithIncrementalRehash())
+	want := make(map[string]int)
+	for i := 0; i < 1000 || !h.Migrating(); i++ {
+		key := "key" + strconv.Itoa(i)
+		h.Set(key, i)
+		want[key] = i
+	}
+	if h.migrated > migrationStep {
+		t.Fatalf("migration already %d slots along", h.migrated)
+	}
+	return h, want
+}
+
+// lastUnmigrated returns the key of want placed last in the old table of
+// a migration, so that it stays unmigrated for as long as possible.
+func lastUnmigrated(h *HashMap[string, int], want map[string]int) string {
+	last, lastIdx := "", -1
+	for key := range want {
+		idx, found := h.findIn(h.old, h.oldSeed, key, h.hashWith(key, h.oldSeed))
+		if found && idx > lastIdx {
+			last, lastIdx = key, idx
+		}
+	}
+	return last
+}
+
+func TestOpsDuringMigration(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(t *testing.T, h *HashMap[string, int], want map[string]int)
+	}{
+		{
+			name: "get unmigrated key",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				key := lastUnmigrated(h, want)
+				if got, found := h.Get(key); !found || got != want[key] {
+					t.Fatalf("Get(%q) = %d, %v, want %d, true", key, got, found, want[key])
+				}
+			},
+		},
+		{
+			name: "update unmigrated key",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				key := lastUnmigrated(h, want)
+				h.Set(key, -1)
+				want[key] = -1
+			},
+		},
+		{
+			name: "update unmigrated key through a variant",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				key := lastUnmigrated(h, want)
+				h.Set(strings.ToUpper(key), -1)
+				want[key] = -1
+			},
+		},
+		{
+			name: "delete unmigrated key",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				key := lastUnmigrated(h, want)
+				if !h.Delete(key) {
+					t.Fatalf("Delete(%q) = false", key)
+				}
+				delete(want, key)
+				if h.Contains(key) {
+					t.Fatalf("Contains(%q) after Delete", key)
+				}
+			},
+		},
+		{
+			name: "delete and reinsert unmigrated key",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				key := lastUnmigrated(h, want)
+				h.Delete(key)
+				h.Set(key, -1)
+				want[key] = -1
+			},
+		},
+		{
+			name: "delete migrated key",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				h.Set("key0", 0) // Migrates the first slots of the old table
+				for key := range want {
+					if h.lookupOld(key) == nil {
+						h.Delete(key)
+						delete(want, key)
+						return
+					}
+				}
+				t.Fatal("no key migrated")
+			},
+		},
+		{
+			name: "insert new keys",
+			ops: func(t *testing.T, h *HashMap[string, int], want map[string]int) {
+				for i := range 10 {
+					key := "new" + strconv.Itoa(i)
+					h.Set(key, i)
+					want[key] = i
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, want := migratingMap(t)
+			tt.ops(t, h, want)
+			if !h.Migrating() {
+				t.Fatal("migration completed during the test")
+			}
+			checkContents(t, h, want)
+
+			h.migrate(h.old.len())
+			checkContents(t, h, want)
+		})
+	}
+}
+
+func TestGrowthPolicyMaxCapacity(t *testing.T) {
+	c := Config{Growth: &GrowthPolicy{MaxCapacity: 100}}
+	if c.Validate() == nil {
+		t.Fatal("Validate accepted a maximum capacity that is not a power of two")
+	}
+
+	tests := []struct {
+		name   string
+		policy GrowthPolicy
+	}{
+		{"power of two", GrowthPolicy{MaxCapacity: 64}},
+		{"prime", GrowthPolicy{MinCapacity: 7, MaxCapacity: 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewWithGrowthPolicy[string, int](tt.policy)
+			want := map[string]int{}
+			for i := range 30 {
+				key := "key" + strconv.Itoa(i)
+				h.Set(key, i)
+				want[key] = i
+			}
+			checkContents(t, h, want)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package hashmaptest provides generators and shrinkers for property-based
+// testing of code built on hashmap. QuickMap and QuickSet can be
+// generated by testing/quick directly. The other generators take a
+// *rand.Rand, so they can back custom generators of any property-testing
+// framework.
+package hashmaptest
+
+import (
+	"iter"
+	"math/rand"
+
+	"github.com/nukilabs/hashmap"
+	"github.com/nukilabs/hashmap/internal/keygen"
+)
+
+// Key returns a random header-like key, such as "sec-FETCH-Mode", in a
+// random case style.
+func Key(r *rand.Rand) string {
+	return keygen.Key(r)
+}
+
+// CaseVariant returns key in a random case style.
+func CaseVariant(r *rand.Rand, key string) string {
+	return keygen.CaseVariant(r, key)
+}
+
+// Keys returns n random keys. Roughly a quarter of them are case variants
+// of earlier keys, exercising case-insensitive key handling.
+func Keys(r *rand.Rand, n int) []string {
+	keys := make([]string, 0, n)
+	for range n {
+		if len(keys) > 0 && r.Intn(4) == 0 {
+			keys = append(keys, CaseVariant(r, keys[r.Intn(len(keys))]))
+		} else {
+			keys = append(keys, Key(r))
+		}
+	}
+	return keys
+}
+
+// Map returns a map with up to n random keys, each mapped to a value
+// produced by value.
+func Map[V any](r *rand.Rand, n int, value func(*rand.Rand) V) *hashmap.HashMap[string, V] {
+	h := hashmap.New[string, V]()
+	for _, key := range Keys(r, n) {
+		h.Set(key, value(r))
+	}
+	return h
+}
+
+// Shrink returns an iterator over smaller variants of h for minimizing a
+// failing input: the empty map, then each half, then h with a single
+// entry removed. Candidates are new maps; h is not modified.
+func Shrink[K comparable, V any](h *hashmap.HashMap[K, V]) iter.Seq[*hashmap.HashMap[K, V]] {
+	return func(yield func(*hashmap.HashMap[K, V]) bool) {
+		n := h.Size()
+		if n == 0 {
+			return
+		}
+		if !yield(hashmap.New[K, V]()) {
+			return
+		}
+
+		if n > 1 {
+			i := 0
+			first, second := h.Partition(func(K, V) bool {
+				i++
+				return i <= n/2
+			})
+			if !yield(first) || !yield(second) {
+				return
+			}
+		}
+
+		for skip := range h.Iter() {
+			smaller := hashmap.New[K, V]()
+			for key, value := range h.Iter() {
+				if key != skip {
+					smaller.Set(key, value)
+				}
+			}
+			if !yield(smaller) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package hashmaptest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"github.com/nukilabs/hashmap"
+	"github.com/nukilabs/hashmap/internal/keygen"
+)
+
+// QuickMap is a HashMap that testing/quick can generate, for use as a
+// property argument:
+//
+//	quick.Check(func(m hashmaptest.QuickMap[string, int]) bool {
+//		return m.Size() == len(m.ToMap())
+//	}, nil)
+//
+// The map's methods are promoted from the embedded HashMap.
+type QuickMap[K comparable, V any] struct {
+	*hashmap.HashMap[K, V]
+}
+
+// Generate implements quick.Generator, producing maps with up to size
+// entries. String keys are header-like names in varied case, and some
+// are case variants of earlier keys. Other keys and all values are
+// generated with quick.Value, falling back to zero values for types it
+// cannot generate.
+func (QuickMap[K, V]) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(QuickMap[K, V]{generate[K, V](r, size)})
+}
+
+// QuickSet is a HashSet that testing/quick can generate, like QuickMap.
+type QuickSet[T comparable] struct {
+	*hashmap.HashSet[T]
+}
+
+// Generate implements quick.Generator, producing sets with up to size
+// elements generated like the keys of QuickMap.
+func (QuickSet[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	s := hashmap.NewHashSet[T]()
+	for item := range generate[T, struct{}](r, size).Keys() {
+		s.Add(item)
+	}
+	return reflect.ValueOf(QuickSet[T]{s})
+}
+
+// generate returns a random map with up to size entries.
+func generate[K comparable, V any](r *rand.Rand, size int) *hashmap.HashMap[K, V] {
+	h := hashmap.New[K, V]()
+	keyType := reflect.TypeFor[K]()
+	valueType := reflect.TypeFor[V]()
+
+	var keys []string
+	for range r.Intn(size + 1) {
+		var key K
+		if keyType.Kind() == reflect.String {
+			s := keygen.Key(r)
+			if len(keys) > 0 && r.Intn(4) == 0 {
+				s = keygen.CaseVariant(r, keys[r.Intn(len(keys))])
+			}
+			keys = append(keys, s)
+			key = reflect.ValueOf(s).Convert(keyType).Interface().(K)
+		} else if v, ok := quick.Value(keyType, r); ok {
+			key = v.Interface().(K)
+		}
+
+		var value V
+		if v, ok := quick.Value(valueType, r); ok {
+			value = v.Interface().(V)
+		}
+		h.Set(key, value)
+	}
+	return h
+}
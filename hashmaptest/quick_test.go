@@ -0,0 +1,31 @@
+package hashmaptest
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestQuickMap(t *testing.T) {
+	check := func(m QuickMap[string, int]) bool {
+		n := 0
+		for key := range m.Keys() {
+			if !m.Contains(key) {
+				return false
+			}
+			n++
+		}
+		return n == m.Size()
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuickSet(t *testing.T) {
+	check := func(s QuickSet[int]) bool {
+		return s.IsSubsetOf(s.Union(s.HashSet)) && s.Difference(s.HashSet).Size() == 0
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,118 @@
+package hashmap
+
+import "iter"
+
+// HashSet is a set built on HashMap, sharing its table and key semantics:
+// string elements are hashed and compared case-insensitively unless the
+// set is created with WithCaseSensitive.
+type HashSet[T comparable] struct {
+	m *HashMap[T, struct{}]
+}
+
+// NewHashSet creates a new, empty HashSet configured by opts, like New.
+func NewHashSet[T comparable](opts ...Option) *HashSet[T] {
+	return &HashSet[T]{m: New[T, struct{}](opts...)}
+}
+
+// HashSetOf creates a new HashSet holding items.
+func HashSetOf[T comparable](items ...T) *HashSet[T] {
+	s := NewHashSet[T](WithCapacity(len(items)))
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+// newLike creates an empty set telling elements apart like s.
+func (s *HashSet[T]) newLike() *HashSet[T] {
+	return NewHashSet[T](s.m.keyIdentity()...)
+}
+
+// Add inserts an element into the set.
+// Returns true if the element was not present before.
+func (s *HashSet[T]) Add(item T) bool {
+	size := s.m.Size()
+	s.m.Set(item, struct{}{})
+	return s.m.Size() > size
+}
+
+// Remove deletes an element from the set.
+// Returns true if the element was found and removed.
+func (s *HashSet[T]) Remove(item T) bool {
+	return s.m.Delete(item)
+}
+
+// Contains checks whether an element is in the set.
+func (s *HashSet[T]) Contains(item T) bool {
+	return s.m.Contains(item)
+}
+
+// Size returns the number of elements in the set.
+func (s *HashSet[T]) Size() int {
+	return s.m.Size()
+}
+
+// Clear removes all elements from the set.
+func (s *HashSet[T]) Clear() {
+	s.m.Clear()
+}
+
+// Iter returns an iterator over the elements of the set, in the spelling
+// they were first added with.
+func (s *HashSet[T]) Iter() iter.Seq[T] {
+	return s.m.Keys()
+}
+
+// Union returns a new set holding the elements of s and other.
+func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
+	result := s.newLike()
+	for item := range s.Iter() {
+		result.Add(item)
+	}
+	for item := range other.Iter() {
+		result.Add(item)
+	}
+	return result
+}
+
+// Intersection returns a new set holding the elements of s that are also
+// in other.
+func (s *HashSet[T]) Intersection(other *HashSet[T]) *HashSet[T] {
+	result := s.newLike()
+	for item := range s.Iter() {
+		if other.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+// Difference returns a new set holding the elements of s that are not in
+// other.
+func (s *HashSet[T]) Difference(other *HashSet[T]) *HashSet[T] {
+	result := s.newLike()
+	for item := range s.Iter() {
+		if !other.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+// IsSubsetOf reports whether every element of s is in other.
+func (s *HashSet[T]) IsSubsetOf(other *HashSet[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	for item := range s.Iter() {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf reports whether every element of other is in s.
+func (s *HashSet[T]) IsSupersetOf(other *HashSet[T]) bool {
+	return other.IsSubsetOf(s)
+}
@@ -0,0 +1,185 @@
+package hashmap
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+// HeaderProfile is the order in which a browser sends request headers.
+// Names are matched case-insensitively.
+type HeaderProfile struct {
+	Name  string
+	Order []string
+}
+
+// Header orders of top-level navigations over HTTP/2, as sent by current
+// browser releases. Pseudo-headers are included so profiles can also
+// order them.
+var (
+	ChromeNavigation = HeaderProfile{
+		Name: "chrome-navigation",
+		Order: []string{
+			":method", ":authority", ":scheme", ":path",
+			"cache-control", "sec-ch-ua", "sec-ch-ua-mobile", "sec-ch-ua-platform",
+			"upgrade-insecure-requests", "user-agent", "accept",
+			"sec-fetch-site", "sec-fetch-mode", "sec-fetch-user", "sec-fetch-dest",
+			"referer", "accept-encoding", "accept-language", "cookie", "priority",
+		},
+	}
+	FirefoxNavigation = HeaderProfile{
+		Name: "firefox-navigation",
+		Order: []string{
+			":method", ":path", ":authority", ":scheme",
+			"user-agent", "accept", "accept-language", "accept-encoding",
+			"referer", "cookie", "upgrade-insecure-requests",
+			"sec-fetch-dest", "sec-fetch-mode", "sec-fetch-site", "sec-fetch-user",
+			"priority", "te",
+		},
+	}
+	SafariNavigation = HeaderProfile{
+		Name: "safari-navigation",
+		Order: []string{
+			":method", ":scheme", ":path", ":authority",
+			"accept", "sec-fetch-site", "cookie", "sec-fetch-dest",
+			"accept-language", "sec-fetch-mode", "user-agent", "referer",
+			"accept-encoding", "priority",
+		},
+	}
+)
+
+// headerEntry holds the values of one header in Headers.
+type headerEntry struct {
+	name   string // Spelling of the name when first added
+	values []string
+	seq    int // Insertion sequence number, ordering headers unknown to the profile
+}
+
+// Headers is a multimap of request headers that iterates in the order of
+// a browser's HeaderProfile. Names are matched case-insensitively and
+// keep the spelling they were first added with. Headers the profile does
+// not know follow all known ones, in the order they were first added.
+type Headers struct {
+	rank    *HashMap[string, int] // Position of each folded name in the profile
+	entries *HashMap[string, *headerEntry]
+	seq     int
+}
+
+// NewHeaders creates an empty Headers ordered by p.
+func NewHeaders(p HeaderProfile) *Headers {
+	rank := New[string, int](WithCapacity(len(p.Order)))
+	for i, name := range p.Order {
+		rank.Set(foldKey(name), i)
+	}
+	return &Headers{
+		rank:    rank,
+		entries: New[string, *headerEntry](),
+	}
+}
+
+// foldKey returns s folded with traits.Latin1CaseFoldTable, so that
+// names differing only in case become equal map keys.
+func foldKey(s string) string {
+	for i := 0; i < len(s); i++ {
+		if traits.Latin1CaseFoldTable[s[i]] != uint16(s[i]) {
+			folded := []byte(s)
+			for j := i; j < len(folded); j++ {
+				folded[j] = byte(traits.Latin1CaseFoldTable[folded[j]])
+			}
+			return string(folded)
+		}
+	}
+	return s
+}
+
+// Add appends a value to the header with the given name.
+func (h *Headers) Add(name, value string) {
+	key := foldKey(name)
+	if entry, found := h.entries.Get(key); found {
+		entry.values = append(entry.values, value)
+		return
+	}
+	h.entries.Set(key, &headerEntry{name: name, values: []string{value}, seq: h.seq})
+	h.seq++
+}
+
+// Set replaces the values of the header with the given name by value.
+// A header that already exists keeps its position and spelling.
+func (h *Headers) Set(name, value string) {
+	key := foldKey(name)
+	if entry, found := h.entries.Get(key); found {
+		entry.values = append(entry.values[:0], value)
+		return
+	}
+	h.Add(name, value)
+}
+
+// Get returns the first value of the header with the given name.
+func (h *Headers) Get(name string) (string, bool) {
+	entry, found := h.entries.Get(foldKey(name))
+	if !found {
+		return "", false
+	}
+	return entry.values[0], true
+}
+
+// Values returns all values of the header with the given name.
+func (h *Headers) Values(name string) []string {
+	entry, found := h.entries.Get(foldKey(name))
+	if !found {
+		return nil
+	}
+	return slices.Clone(entry.values)
+}
+
+// Del removes the header with the given name.
+// Returns true if the header was found and removed.
+func (h *Headers) Del(name string) bool {
+	return h.entries.Delete(foldKey(name))
+}
+
+// Len returns the number of distinct header names.
+func (h *Headers) Len() int {
+	return h.entries.Size()
+}
+
+// All returns an iterator over name-value pairs in profile order. A
+// header with several values yields one pair per value.
+func (h *Headers) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, entry := range h.ordered() {
+			for _, value := range entry.values {
+				if !yield(entry.name, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ordered returns the entries sorted by their position in the profile,
+// with unknown headers after all known ones in insertion order.
+func (h *Headers) ordered() []*headerEntry {
+	type ranked struct {
+		rank  int
+		entry *headerEntry
+	}
+
+	known := h.rank.Size()
+	entries := make([]ranked, 0, h.entries.Size())
+	for key, entry := range h.entries.Iter() {
+		rank, found := h.rank.Get(key)
+		if !found {
+			rank = known + entry.seq
+		}
+		entries = append(entries, ranked{rank, entry})
+	}
+	slices.SortFunc(entries, func(a, b ranked) int { return a.rank - b.rank })
+
+	result := make([]*headerEntry, len(entries))
+	for i, r := range entries {
+		result[i] = r.entry
+	}
+	return result
+}
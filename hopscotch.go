@@ -0,0 +1,234 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+const (
+	hopNeighborhood = 32 // Slots an entry may be away from its home bucket
+	hopMaximumLoad  = 8  // Expands at 7/8 load factor
+)
+
+// HopscotchMap is a hash table using hopscotch hashing: every entry is kept
+// within a small neighborhood of its home bucket, and a per-bucket bitmap
+// records which neighborhood slots hold entries for that bucket. Lookups
+// touch a few adjacent slots, and the table stays efficient at load factors
+// where open addressing with long probe chains degrades.
+// String keys are hashed and compared case-insensitively.
+type HopscotchMap[K comparable, V any] struct {
+	table    []*Pair[K, V]
+	hops     []uint32 // Neighborhood bitmap of each home bucket
+	size     int
+	capacity int
+	seed     maphash.Seed // Seed for hashing non-string keys
+}
+
+// NewHopscotch creates a new HopscotchMap with the default initial capacity.
+func NewHopscotch[K comparable, V any]() *HopscotchMap[K, V] {
+	return &HopscotchMap[K, V]{
+		table:    make([]*Pair[K, V], initialCapacity),
+		hops:     make([]uint32, initialCapacity),
+		capacity: initialCapacity,
+		seed:     maphash.MakeSeed(),
+	}
+}
+
+// hash computes the hash value for a key.
+// For strings, uses case-insensitive hashing.
+func (h *HopscotchMap[K, V]) hash(key K) uint32 {
+	switch k := any(key).(type) {
+	case string:
+		return traits.CaseFoldingHash(k)
+	default:
+		return uint32(maphash.Comparable(h.seed, key))
+	}
+}
+
+// home returns the home bucket index for a key.
+func (h *HopscotchMap[K, V]) home(key K) int {
+	return int(h.hash(key) & uint32(h.capacity-1))
+}
+
+// distance returns how far slot to lies after slot from, wrapping around.
+func (h *HopscotchMap[K, V]) distance(from, to int) int {
+	return (to - from) & (h.capacity - 1)
+}
+
+// find locates the slot holding a key by scanning its home bucket's
+// neighborhood bitmap. Returns the index and whether the key was found.
+func (h *HopscotchMap[K, V]) find(key K) (int, bool) {
+	home := h.home(key)
+	for hop := h.hops[home]; hop != 0; hop &= hop - 1 {
+		idx := (home + bits.TrailingZeros32(hop)) & (h.capacity - 1)
+		if equalKeys(h.table[idx].Key, key) {
+			return idx, true
+		}
+	}
+	return 0, false
+}
+
+// insert places a pair whose key is not yet in the table.
+// Returns false if no free slot could be moved into the neighborhood.
+func (h *HopscotchMap[K, V]) insert(pair *Pair[K, V]) bool {
+	home := h.home(pair.Key)
+
+	free := -1
+	for i := range h.capacity {
+		idx := (home + i) & (h.capacity - 1)
+		if h.table[idx] == nil {
+			free = idx
+			break
+		}
+	}
+	if free < 0 {
+		return false
+	}
+
+	for h.distance(home, free) >= hopNeighborhood {
+		free = h.hop(free)
+		if free < 0 {
+			return false
+		}
+	}
+
+	h.table[free] = pair
+	h.hops[home] |= 1 << h.distance(home, free)
+	return true
+}
+
+// hop moves the free slot closer to the front by relocating an entry from
+// one of the preceding buckets into it, keeping that entry inside its own
+// neighborhood. Returns the newly freed slot, or -1 if no entry can move.
+func (h *HopscotchMap[K, V]) hop(free int) int {
+	for d := hopNeighborhood - 1; d > 0; d-- {
+		bucket := (free - d) & (h.capacity - 1)
+		for hop := h.hops[bucket]; hop != 0; hop &= hop - 1 {
+			offset := bits.TrailingZeros32(hop)
+			if offset >= d {
+				break
+			}
+
+			idx := (bucket + offset) & (h.capacity - 1)
+			h.table[free] = h.table[idx]
+			h.table[idx] = nil
+			h.hops[bucket] = h.hops[bucket]&^(1<<offset) | 1<<d
+			return idx
+		}
+	}
+	return -1
+}
+
+// grow doubles the table until every existing entry fits.
+func (h *HopscotchMap[K, V]) grow() {
+	old := h.table
+	for {
+		h.capacity *= 2
+		h.table = make([]*Pair[K, V], h.capacity)
+		h.hops = make([]uint32, h.capacity)
+		if h.reinsert(old) {
+			return
+		}
+	}
+}
+
+// reinsert places every entry of old into the current table.
+// Returns false if an entry could not be placed.
+func (h *HopscotchMap[K, V]) reinsert(old []*Pair[K, V]) bool {
+	for _, pair := range old {
+		if pair != nil && !h.insert(pair) {
+			return false
+		}
+	}
+	return true
+}
+
+// Set inserts or updates a key-value pair.
+// If the key exists, only the value is updated.
+// If the key is new, both key and value are inserted.
+func (h *HopscotchMap[K, V]) Set(key K, value V) {
+	idx, found := h.find(key)
+	if found {
+		h.table[idx].Value = value
+		return
+	}
+
+	if (h.size+1)*hopMaximumLoad > h.capacity*(hopMaximumLoad-1) {
+		h.grow()
+	}
+
+	pair := &Pair[K, V]{
+		Key:   key,
+		Value: value,
+	}
+	for !h.insert(pair) {
+		h.grow()
+	}
+	h.size++
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found, zero value and false otherwise.
+func (h *HopscotchMap[K, V]) Get(key K) (V, bool) {
+	idx, found := h.find(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return h.table[idx].Value, true
+}
+
+// Contains checks whether a key exists in the map.
+func (h *HopscotchMap[K, V]) Contains(key K) bool {
+	_, found := h.find(key)
+	return found
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (h *HopscotchMap[K, V]) Delete(key K) bool {
+	idx, found := h.find(key)
+	if !found {
+		return false
+	}
+
+	home := h.home(key)
+	h.hops[home] &^= 1 << h.distance(home, idx)
+	h.table[idx] = nil
+	h.size--
+	return true
+}
+
+// Clear removes all elements from the map.
+func (h *HopscotchMap[K, V]) Clear() {
+	h.table = make([]*Pair[K, V], initialCapacity)
+	h.hops = make([]uint32, initialCapacity)
+	h.capacity = initialCapacity
+	h.size = 0
+}
+
+// Size returns the number of key-value pairs in the map.
+func (h *HopscotchMap[K, V]) Size() int {
+	return h.size
+}
+
+// Capacity returns the current capacity of the underlying table.
+func (h *HopscotchMap[K, V]) Capacity() int {
+	return h.capacity
+}
+
+// Iter returns an iterator over key-value pairs.
+func (h *HopscotchMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, pair := range h.table {
+			if pair != nil {
+				if !yield(pair.Key, pair.Value) {
+					return
+				}
+			}
+		}
+	}
+}
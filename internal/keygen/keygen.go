@@ -0,0 +1,70 @@
+// Package keygen generates random HTTP header-like string keys with
+// varied case, for property-based testing.
+package keygen
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Fragments that generated keys are assembled from.
+var fragments = []string{
+	"accept", "agent", "cache", "ch", "content", "control", "cookie",
+	"dest", "encoding", "fetch", "for", "forwarded", "language", "length",
+	"mode", "origin", "platform", "referer", "sec", "site", "type", "ua",
+	"upgrade", "user", "x",
+}
+
+// Key returns a random header-like key of one to three fragments,
+// such as "Sec-Fetch-Mode", in a random case style.
+func Key(r *rand.Rand) string {
+	n := 1 + r.Intn(3)
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = fragments[r.Intn(len(fragments))]
+	}
+	return CaseVariant(r, strings.Join(parts, "-"))
+}
+
+// CaseVariant returns key in a random case style: lower, upper,
+// canonical header case, or mixed case per letter.
+func CaseVariant(r *rand.Rand, key string) string {
+	switch r.Intn(4) {
+	case 0:
+		return strings.ToLower(key)
+	case 1:
+		return strings.ToUpper(key)
+	case 2:
+		b := []byte(strings.ToLower(key))
+		for i := range b {
+			if i == 0 || b[i-1] == '-' {
+				b[i] = upper(b[i])
+			}
+		}
+		return string(b)
+	default:
+		b := []byte(key)
+		for i := range b {
+			if r.Intn(2) == 0 {
+				b[i] = upper(b[i])
+			} else {
+				b[i] = lower(b[i])
+			}
+		}
+		return string(b)
+	}
+}
+
+func upper(b byte) byte {
+	if 'a' <= b && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
+
+func lower(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b - 'A' + 'a'
+	}
+	return b
+}
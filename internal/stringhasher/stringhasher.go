@@ -1,6 +1,6 @@
 package stringhasher
 
-import "github.com/nukilabs/hashmap/internal/rapidhash"
+import "github.com/nukilabs/hashmap/rapidhash"
 
 const FlagCount = 8 // Save 8 bits to be used as flags
 
@@ -0,0 +1,96 @@
+package hashmap
+
+import (
+	"sync"
+	"unsafe"
+)
+
+const (
+	internChunkSize = 4096                // Size of each arena chunk
+	internMaxInline = internChunkSize / 4 // Longer strings get their own allocation
+)
+
+// Interner deduplicates strings by keeping a single copy of each, packed
+// into shared arena chunks. Maps sharing an Interner store every distinct
+// key once, no matter how many maps contain it, and never retain the
+// caller's original string memory. Strings are compared like HashMap
+// compares keys by default, ignoring case, so keys differing only in
+// case share the copy of the spelling seen first. Case-sensitive maps
+// sharing the Interner still get a copy of their exact spelling.
+// Interned strings are never freed. An Interner is safe for concurrent use.
+type Interner struct {
+	mu       sync.Mutex
+	chunk    []byte
+	strings  *HashMap[string, string]
+	variants *HashMap[string, string] // Other spellings of strings, interned for case-sensitive maps
+}
+
+// NewInterner creates a new, empty Interner.
+func NewInterner() *Interner {
+	return &Interner{
+		strings: New[string, string](),
+	}
+}
+
+// Intern returns the canonical copy of s, the first string interned
+// that equals s ignoring case, copying s into the arena if there is none.
+func (in *Interner) Intern(s string) string {
+	return in.intern(s, false)
+}
+
+// intern returns the canonical copy of s like Intern, or when exact is
+// set, a copy spelled exactly like s.
+func (in *Interner) intern(s string, exact bool) string {
+	if s == "" {
+		return ""
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	interned, found := in.strings.Get(s)
+	if !found {
+		interned = in.copy(s)
+		in.strings.Set(interned, interned)
+		return interned
+	}
+	if !exact || interned == s {
+		return interned
+	}
+
+	if in.variants == nil {
+		in.variants = New[string, string](WithCaseSensitive())
+	}
+	if variant, found := in.variants.Get(s); found {
+		return variant
+	}
+	variant := in.copy(s)
+	in.variants.Set(variant, variant)
+	return variant
+}
+
+// copy stores s in the arena and returns a string backed by the copy.
+// The caller must hold the lock.
+func (in *Interner) copy(s string) string {
+	if len(s) > internMaxInline {
+		return string([]byte(s))
+	}
+
+	if len(s) > cap(in.chunk)-len(in.chunk) {
+		in.chunk = make([]byte, 0, internChunkSize)
+	}
+	start := len(in.chunk)
+	in.chunk = append(in.chunk, s...)
+	return unsafe.String(&in.chunk[start], len(s))
+}
+
+// Len returns the number of distinct copies interned, counting each
+// spelling kept for case-sensitive maps.
+func (in *Interner) Len() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.variants == nil {
+		return in.strings.Size()
+	}
+	return in.strings.Size() + in.variants.Size()
+}
@@ -0,0 +1,166 @@
+package hashmap
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// IterChunks returns an iterator over consecutive batches of up to n
+// key-value pairs. Every batch except possibly the last has exactly n
+// entries. Each batch is a freshly allocated slice that the caller may
+// retain. It panics if n is less than 1.
+func (h *HashMap[K, V]) IterChunks(n int) iter.Seq[[]Pair[K, V]] {
+	if n < 1 {
+		panic("hashmap: chunk size must be at least 1")
+	}
+
+	return func(yield func([]Pair[K, V]) bool) {
+		chunk := make([]Pair[K, V], 0, min(n, h.size))
+		for pair := range h.slots() {
+			chunk = append(chunk, pair.Pair)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]Pair[K, V], 0, n)
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// DeleteFunc deletes every entry for which del returns true, in a single
+// pass over the table, so matching entries can be removed without first
+// collecting their keys. del must not modify the map.
+// Returns the number of entries deleted.
+func (h *HashMap[K, V]) DeleteFunc(del func(K, V) bool) int {
+	if h.deferred {
+		panic("hashmap: map read before Build")
+	}
+	h.migrate(h.old.len())
+
+	n := 0
+	for i := range h.table.len() {
+		if s := h.table.at(i); s.used && del(s.Key, s.Value) {
+			h.remove(s, false)
+			n++
+		}
+	}
+	h.shrink()
+	return n
+}
+
+// Cursor is a resumable scan over a HashMap, returning entries in
+// batches so that scanning a large map can be spread over time. Between
+// batches the map may be modified, even if that grows the table: entries
+// deleted before they are reached are not returned, updated entries are
+// returned with their new value, and entries inserted after the cursor
+// was created may or may not be returned. No entry is returned twice.
+// After the table is replaced the cursor keeps the table it started with
+// reachable until the scan ends.
+type Cursor[K comparable, V any] struct {
+	h     *HashMap[K, V]
+	table *slotTable[K, V] // Table the scan walks, which h may have replaced
+	pos   int              // Index of the next slot of table to visit
+}
+
+// Cursor returns a cursor positioned at the start of the map. A migration
+// pending after RotateSeed is completed first.
+func (h *HashMap[K, V]) Cursor() *Cursor[K, V] {
+	if h.deferred {
+		panic("hashmap: map read before Build")
+	}
+	h.migrate(h.old.len())
+	table := h.table
+	return &Cursor[K, V]{h: h, table: &table}
+}
+
+// Next returns the next batch of up to n entries, as a freshly allocated
+// slice. A batch shorter than n, possibly empty, is only returned once
+// the scan is complete. It panics if n is less than 1.
+func (c *Cursor[K, V]) Next(n int) []Pair[K, V] {
+	if n < 1 {
+		panic("hashmap: batch size must be at least 1")
+	}
+
+	var batch []Pair[K, V]
+	for ; c.pos < c.table.len() && len(batch) < n; c.pos++ {
+		s := c.table.at(c.pos)
+		if !s.used {
+			continue
+		}
+		if !c.h.table.same(c.table) {
+			if s = c.h.lookup(s.Key); s == nil {
+				continue
+			}
+		}
+		batch = append(batch, s.Pair)
+	}
+	if c.pos == c.table.len() {
+		c.table = nil
+	}
+	return batch
+}
+
+// Done reports whether the scan is complete.
+func (c *Cursor[K, V]) Done() bool {
+	return c.table == nil
+}
+
+// ParallelRange calls fn for every key-value pair, splitting the table
+// into segments processed by workers goroutines, or GOMAXPROCS
+// goroutines if workers is less than 1. It returns once all calls have
+// returned. fn is called concurrently and must be safe for that; the map
+// must not be modified until ParallelRange returns.
+func (h *HashMap[K, V]) ParallelRange(workers int, fn func(K, V)) {
+	if h.deferred {
+		panic("hashmap: map read before Build")
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	h.migrate(h.old.len())
+
+	table := h.table
+	segment := (table.len() + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < table.len(); start += segment {
+		wg.Go(func() {
+			for i := start; i < min(start+segment, table.len()); i++ {
+				if s := table.at(i); s.used {
+					fn(s.Key, s.Value)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// Drain returns an iterator that yields every key-value pair and removes
+// it from the map, leaving the map empty once iteration completes. If the
+// loop stops early, the entries not yet yielded stay in the map. The map
+// must not be modified other than through Drain until iteration ends.
+func (h *HashMap[K, V]) Drain() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for s := range h.slots() {
+			pair := s.Pair
+			*s = slot[K, V]{deleted: true}
+			h.size--
+			h.tombstones++
+			if h.times != nil {
+				h.times.Delete(pair.Key)
+			}
+			if h.journal != nil {
+				h.journal.Append(Record[K, V]{Op: OpDelete, Key: pair.Key})
+			}
+
+			if !yield(pair.Key, pair.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package hashmap
+
+import "iter"
+
+// Joined holds the values associated with one key in two joined maps.
+type Joined[A, B any] struct {
+	Left    A
+	Right   B
+	Matched bool // Whether the key was present in the right map
+}
+
+// Join returns an iterator over the inner join of a and b.
+// It yields every key of a that is also present in b, together with
+// both values. Keys are looked up in b using b's key identity.
+func Join[K comparable, A, B any](a *HashMap[K, A], b *HashMap[K, B]) iter.Seq2[K, Joined[A, B]] {
+	return func(yield func(K, Joined[A, B]) bool) {
+		for key, left := range a.Iter() {
+			right, found := b.Get(key)
+			if !found {
+				continue
+			}
+			if !yield(key, Joined[A, B]{Left: left, Right: right, Matched: true}) {
+				return
+			}
+		}
+	}
+}
+
+// LeftJoin returns an iterator over the left join of a and b.
+// It yields every key of a together with its value and, if present,
+// the value from b. Matched reports whether the key was found in b.
+func LeftJoin[K comparable, A, B any](a *HashMap[K, A], b *HashMap[K, B]) iter.Seq2[K, Joined[A, B]] {
+	return func(yield func(K, Joined[A, B]) bool) {
+		for key, left := range a.Iter() {
+			right, found := b.Get(key)
+			if !yield(key, Joined[A, B]{Left: left, Right: right, Matched: found}) {
+				return
+			}
+		}
+	}
+}
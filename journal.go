@@ -0,0 +1,139 @@
+package hashmap
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+	"slices"
+)
+
+// Op identifies the kind of a journaled mutation.
+type Op uint8
+
+const (
+	OpSet    Op = iota + 1 // A key was inserted or updated
+	OpDelete               // A key was deleted
+	OpClear                // The map was cleared
+)
+
+// Record is a single journaled mutation.
+// Key is unused for OpClear, and Value is only used for OpSet.
+type Record[K comparable, V any] struct {
+	Op    Op
+	Key   K
+	Value V
+}
+
+// Journal receives every mutation applied to a map it is attached to,
+// in the order the mutations happen.
+type Journal[K comparable, V any] interface {
+	Append(r Record[K, V])
+}
+
+// SetJournal attaches a journal that receives every subsequent Set,
+// successful Delete and Clear, replacing every journal attached so far,
+// including a PrefixIndex. Passing nil detaches them all. Use AddJournal
+// to keep the journals already attached.
+func (h *HashMap[K, V]) SetJournal(j Journal[K, V]) {
+	h.journal = j
+}
+
+// AddJournal attaches a journal like SetJournal, in addition to the
+// journals already attached. Each mutation is passed to the journals in
+// the order they were attached.
+func (h *HashMap[K, V]) AddJournal(j Journal[K, V]) {
+	if h.journal == nil {
+		h.journal = j
+		return
+	}
+	h.journal = MultiJournal(h.journal, j)
+}
+
+// MultiJournal returns a Journal that passes every record on to each of
+// js in turn, like io.MultiWriter, so that several journals can follow
+// one map.
+func MultiJournal[K comparable, V any](js ...Journal[K, V]) Journal[K, V] {
+	return multiJournal[K, V](slices.Clone(js))
+}
+
+// multiJournal is a Journal fanning records out to several journals.
+type multiJournal[K comparable, V any] []Journal[K, V]
+
+// Append passes r on to every journal.
+func (m multiJournal[K, V]) Append(r Record[K, V]) {
+	for _, j := range m {
+		j.Append(r)
+	}
+}
+
+// Replay applies the records in order, reconstructing the state of the
+// map they were recorded from. If a journal is attached, the replayed
+// mutations are journaled as well.
+func (h *HashMap[K, V]) Replay(records []Record[K, V]) {
+	for _, r := range records {
+		switch r.Op {
+		case OpSet:
+			h.Set(r.Key, r.Value)
+		case OpDelete:
+			h.Delete(r.Key)
+		case OpClear:
+			h.Clear()
+		}
+	}
+}
+
+// MemoryJournal is a Journal that keeps records in memory.
+type MemoryJournal[K comparable, V any] struct {
+	Records []Record[K, V]
+}
+
+// Append adds a record to the journal.
+func (j *MemoryJournal[K, V]) Append(r Record[K, V]) {
+	j.Records = append(j.Records, r)
+}
+
+// EncoderJournal is a Journal that gob-encodes records to a writer,
+// suitable for crash recovery or mirroring to another process.
+// Since Append cannot fail, the first write error is retained and
+// reported by Err; later records are dropped.
+type EncoderJournal[K comparable, V any] struct {
+	enc *gob.Encoder
+	err error
+}
+
+// NewEncoderJournal creates a journal writing records to w.
+func NewEncoderJournal[K comparable, V any](w io.Writer) *EncoderJournal[K, V] {
+	return &EncoderJournal[K, V]{enc: gob.NewEncoder(w)}
+}
+
+// Append encodes a record to the underlying writer.
+func (j *EncoderJournal[K, V]) Append(r Record[K, V]) {
+	if j.err != nil {
+		return
+	}
+	j.err = j.enc.Encode(r)
+}
+
+// Err returns the first error encountered while writing records.
+func (j *EncoderJournal[K, V]) Err() error {
+	return j.err
+}
+
+// DecodeJournal reads records written by an EncoderJournal until EOF.
+// If the stream ends with a partially written record, as after a crash,
+// the records decoded so far are returned along with the error.
+func DecodeJournal[K comparable, V any](r io.Reader) ([]Record[K, V], error) {
+	dec := gob.NewDecoder(r)
+
+	var records []Record[K, V]
+	for {
+		var rec Record[K, V]
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return records, err
+		}
+		records = append(records, rec)
+	}
+}
@@ -0,0 +1,81 @@
+package hashmap
+
+import (
+	"hash/maphash"
+	"math"
+
+	"github.com/nukilabs/hashmap/rapidhash"
+)
+
+// comparableSeed seeds maphash for keys without a portable encoding.
+var comparableSeed = maphash.MakeSeed()
+
+// hashComparable hashes a key that is not a string. Booleans, integers
+// and floats hash the 8-byte encoding of their value with rapidhash, so
+// they hash identically in every process. Other keys, such as structs,
+// pointers and named types, are first hashed with maphash, which is
+// only stable within a process.
+func hashComparable[K comparable](key K, seed uint64) uint32 {
+	v, ok := portableBits(key)
+	if !ok {
+		v = maphash.Comparable(comparableSeed, key)
+	}
+	return uint32(rapidhash.Sum64Uint64(v, seed))
+}
+
+// portableBits returns a 64-bit encoding of a boolean, integer or float
+// key that equal keys share.
+// Returns false for keys of any other type.
+func portableBits[K comparable](key K) (uint64, bool) {
+	switch k := any(key).(type) {
+	case int:
+		return uint64(k), true
+	case int8:
+		return uint64(k), true
+	case int16:
+		return uint64(k), true
+	case int32:
+		return uint64(k), true
+	case int64:
+		return uint64(k), true
+	case uint:
+		return uint64(k), true
+	case uint8:
+		return uint64(k), true
+	case uint16:
+		return uint64(k), true
+	case uint32:
+		return uint64(k), true
+	case uint64:
+		return k, true
+	case uintptr:
+		return uint64(k), true
+	case bool:
+		if k {
+			return 1, true
+		}
+		return 0, true
+	case float32:
+		if k == 0 {
+			return 0, true // -0 == +0
+		}
+		return uint64(math.Float32bits(k)), true
+	case float64:
+		if k == 0 {
+			return 0, true
+		}
+		return math.Float64bits(k), true
+	}
+	return 0, false
+}
+
+// portableKeys reports whether keys of type K hash identically in every
+// process given the same seed.
+func portableKeys[K comparable]() bool {
+	var key K
+	if _, ok := any(key).(string); ok {
+		return true
+	}
+	_, ok := portableBits(key)
+	return ok
+}
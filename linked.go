@@ -0,0 +1,158 @@
+package hashmap
+
+import (
+	"container/list"
+	"iter"
+)
+
+// LinkedHashMap is a HashMap that remembers the order of its entries,
+// like Chromium's WTF LinkedHashMap. Entries are kept in a doubly-linked
+// list, in insertion order unless moved with MoveToFront or MoveToEnd,
+// which also makes it suitable for LRU bookkeeping. Keys are told apart
+// like in HashMap.
+type LinkedHashMap[K comparable, V any] struct {
+	order    *list.List                 // Entries as *Pair[K, V], front to end
+	elements *HashMap[K, *list.Element] // List elements by key
+}
+
+// NewLinked creates a new, empty LinkedHashMap whose index is configured
+// by opts, like New.
+func NewLinked[K comparable, V any](opts ...Option) *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{
+		order:    list.New(),
+		elements: New[K, *list.Element](opts...),
+	}
+}
+
+// pair returns the entry held by a list element.
+func pair[K comparable, V any](e *list.Element) *Pair[K, V] {
+	return e.Value.(*Pair[K, V])
+}
+
+// Set inserts or updates a key-value pair. New keys are appended at the
+// end, in canonical form; updating a key keeps its position. Keys failing
+// validation are handled like in HashMap.Set and never enter the order.
+func (m *LinkedHashMap[K, V]) Set(key K, value V) {
+	if e, found := m.elements.Get(key); found {
+		pair[K, V](e).Value = value
+		return
+	}
+	key = m.elements.canonical(key)
+	if m.elements.rejected(key) {
+		return
+	}
+	m.elements.Set(key, m.order.PushBack(&Pair[K, V]{Key: key, Value: value}))
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found, zero value and false otherwise.
+func (m *LinkedHashMap[K, V]) Get(key K) (V, bool) {
+	e, found := m.elements.Get(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return pair[K, V](e).Value, true
+}
+
+// Contains checks if a key exists in the map.
+func (m *LinkedHashMap[K, V]) Contains(key K) bool {
+	return m.elements.Contains(key)
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (m *LinkedHashMap[K, V]) Delete(key K) bool {
+	e, found := m.elements.Get(key)
+	if !found {
+		return false
+	}
+	m.order.Remove(e)
+	m.elements.Delete(key)
+	return true
+}
+
+// Clear removes all elements from the map.
+func (m *LinkedHashMap[K, V]) Clear() {
+	m.order.Init()
+	m.elements.Clear()
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *LinkedHashMap[K, V]) Size() int {
+	return m.order.Len()
+}
+
+// MoveToFront moves a key's entry to the front of the order.
+// Returns false if the key does not exist.
+func (m *LinkedHashMap[K, V]) MoveToFront(key K) bool {
+	e, found := m.elements.Get(key)
+	if found {
+		m.order.MoveToFront(e)
+	}
+	return found
+}
+
+// MoveToEnd moves a key's entry to the end of the order, such as after
+// accessing it when the front is evicted first.
+// Returns false if the key does not exist.
+func (m *LinkedHashMap[K, V]) MoveToEnd(key K) bool {
+	e, found := m.elements.Get(key)
+	if found {
+		m.order.MoveToBack(e)
+	}
+	return found
+}
+
+// Front returns the first entry in order.
+// Returns false if the map is empty.
+func (m *LinkedHashMap[K, V]) Front() (K, V, bool) {
+	return m.entry(m.order.Front())
+}
+
+// Back returns the last entry in order.
+// Returns false if the map is empty.
+func (m *LinkedHashMap[K, V]) Back() (K, V, bool) {
+	return m.entry(m.order.Back())
+}
+
+// entry returns the key and value held by e, which may be nil.
+func (m *LinkedHashMap[K, V]) entry(e *list.Element) (K, V, bool) {
+	if e == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	p := pair[K, V](e)
+	return p.Key, p.Value, true
+}
+
+// Iter returns an iterator over key-value pairs from front to end. The
+// entry just yielded may be deleted or moved during iteration; moving it
+// to the end before the loop finishes visits it again.
+func (m *LinkedHashMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.order.Front(); e != nil; {
+			next := e.Next()
+			p := pair[K, V](e)
+			if !yield(p.Key, p.Value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs from end to front.
+func (m *LinkedHashMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.order.Back(); e != nil; {
+			prev := e.Prev()
+			p := pair[K, V](e)
+			if !yield(p.Key, p.Value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
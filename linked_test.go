@@ -0,0 +1,28 @@
+package hashmap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinkedHashMapRejectedKeys(t *testing.T) {
+	m := NewLinked[string, int](WithHeaderKeys(ValidateError), WithKeyCanonicalizer(strings.TrimSpace))
+	m.Set(" Accept ", 1)
+	for range 3 {
+		m.Set("bad name\r\n", 2)
+	}
+
+	if m.Contains("bad name\r\n") {
+		t.Fatal("rejected key is in the map")
+	}
+	if m.Size() != 1 {
+		t.Fatalf("Size = %d, want 1", m.Size())
+	}
+	var keys []string
+	for key := range m.Iter() {
+		keys = append(keys, key)
+	}
+	if len(keys) != 1 || keys[0] != "Accept" {
+		t.Fatalf("Iter yielded keys %q, want [\"Accept\"]", keys)
+	}
+}
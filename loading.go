@@ -0,0 +1,205 @@
+package hashmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrLoaderPanic is wrapped by the error a background reload of a
+// LoadingCache fails with when the loader panics.
+var ErrLoaderPanic = errors.New("hashmap: loader panicked")
+
+// RefreshPolicy controls when a LoadingCache reloads its entries.
+type RefreshPolicy struct {
+	RefreshAfter time.Duration // Age after which a read triggers a background reload
+	MaxStale     time.Duration // Age beyond which a value is not served and reads wait for a reload; unlimited when zero
+	ErrorBackoff time.Duration // Delay before retrying a failed background reload
+}
+
+// loadingEntry is a value stored in a LoadingCache with its load state.
+type loadingEntry[V any] struct {
+	value      V
+	loaded     time.Time // When the value was loaded
+	retryAt    time.Time // Earliest time to retry after a failed refresh
+	refreshing bool      // Whether a background reload is in progress
+}
+
+// LoadingCache is a cache that loads missing values with a loader and
+// refreshes them ahead of time: once an entry is older than
+// RefreshAfter, reads keep returning the cached value while it is
+// reloaded in the background. Entries older than MaxStale are reloaded
+// synchronously instead. Concurrent reads of the same missing key share
+// a single loader call. LoadingCache is safe for concurrent use.
+type LoadingCache[K comparable, V any] struct {
+	mu     sync.Mutex
+	m      *HashMap[K, *loadingEntry[V]]
+	loads  *HashMap[K, *loadCall[V]] // In-flight synchronous loads
+	loader func(context.Context, K) (V, error)
+	policy RefreshPolicy
+	stats  CacheStats
+}
+
+// NewLoadingCache creates a new LoadingCache that loads values with
+// loader and refreshes them according to p. It panics if p.RefreshAfter
+// is not positive or p.MaxStale is below it.
+func NewLoadingCache[K comparable, V any](loader func(K) (V, error), p RefreshPolicy) *LoadingCache[K, V] {
+	return NewLoadingCacheCtx(func(_ context.Context, key K) (V, error) {
+		return loader(key)
+	}, p)
+}
+
+// NewLoadingCacheCtx is like NewLoadingCache, but the loader takes the
+// context of the read that triggered the load, see GetCtx.
+func NewLoadingCacheCtx[K comparable, V any](loader func(context.Context, K) (V, error), p RefreshPolicy) *LoadingCache[K, V] {
+	switch {
+	case p.RefreshAfter <= 0:
+		panic("hashmap: refresh interval must be positive")
+	case p.MaxStale != 0 && p.MaxStale < p.RefreshAfter:
+		panic("hashmap: maximum staleness is below refresh interval")
+	}
+
+	return &LoadingCache[K, V]{
+		m:      New[K, *loadingEntry[V]](),
+		loads:  New[K, *loadCall[V]](),
+		loader: loader,
+		policy: p,
+	}
+}
+
+// Get returns the value for a key, loading it if it is missing or older
+// than MaxStale. A value older than RefreshAfter is returned as is, and
+// a background reload is started unless one is running or a failed one
+// is backing off. Errors from synchronous loads are returned and not
+// cached; failed background reloads keep serving the previous value.
+func (c *LoadingCache[K, V]) Get(key K) (V, error) {
+	return c.GetCtx(context.Background(), key)
+}
+
+// GetCtx is like Get, but passes ctx to the loader. A synchronous load
+// runs with ctx, and a caller waiting for a load started by another
+// caller stops waiting and returns ctx.Err() when ctx is done; the load
+// itself carries on for the others. A background reload runs with ctx's
+// values but is not canceled with it, as it outlives the read.
+func (c *LoadingCache[K, V]) GetCtx(ctx context.Context, key K) (V, error) {
+	c.mu.Lock()
+	now := time.Now()
+	if entry, found := c.m.Get(key); found {
+		age := now.Sub(entry.loaded)
+		if c.policy.MaxStale == 0 || age < c.policy.MaxStale {
+			if age >= c.policy.RefreshAfter && !entry.refreshing && !now.Before(entry.retryAt) {
+				entry.refreshing = true
+				go c.refresh(context.WithoutCancel(ctx), key, entry)
+			}
+			c.stats.Hits++
+			c.mu.Unlock()
+			return entry.value, nil
+		}
+	}
+	c.stats.Misses++
+	return c.load(ctx, key)
+}
+
+// load loads the value for a key synchronously, sharing the loader call
+// with concurrent callers. The caller must hold the lock, which load
+// releases.
+func (c *LoadingCache[K, V]) load(ctx context.Context, key K) (V, error) {
+	if call, found := c.loads.Get(key); found {
+		c.mu.Unlock()
+		select {
+		case <-call.ready:
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+		if !call.done {
+			return c.GetCtx(ctx, key)
+		}
+		return call.value, call.err
+	}
+
+	call := &loadCall[V]{ready: make(chan struct{})}
+	c.loads.Set(key, call)
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.loads.Delete(key)
+		if call.done {
+			c.stats.recordLoad(call.err)
+		}
+		if call.done && call.err == nil {
+			c.m.Set(key, &loadingEntry[V]{value: call.value, loaded: time.Now()})
+		}
+		c.mu.Unlock()
+		close(call.ready)
+	}()
+	call.value, call.err = c.loader(ctx, key)
+	call.done = true
+	return call.value, call.err
+}
+
+// refresh reloads an entry in the background. The result is discarded
+// if the entry was replaced or deleted meanwhile.
+func (c *LoadingCache[K, V]) refresh(ctx context.Context, key K, entry *loadingEntry[V]) {
+	value, err := c.reload(ctx, key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.recordLoad(err)
+	entry.refreshing = false
+	if current, found := c.m.Get(key); !found || current != entry {
+		return
+	}
+	if err != nil {
+		entry.retryAt = time.Now().Add(c.policy.ErrorBackoff)
+		return
+	}
+	c.m.Set(key, &loadingEntry[V]{value: value, loaded: time.Now()})
+}
+
+// reload calls the loader for a background reload. A panic is turned
+// into an error wrapping ErrLoaderPanic, since no caller is there to
+// receive it, so that the entry backs off and is marked as no longer
+// refreshing like after any failed reload.
+func (c *LoadingCache[K, V]) reload(ctx context.Context, key K) (value V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrLoaderPanic, r)
+		}
+	}()
+	return c.loader(ctx, key)
+}
+
+// Set stores a value for a key as if it had just been loaded.
+func (c *LoadingCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m.Set(key, &loadingEntry[V]{value: value, loaded: time.Now()})
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was found and deleted.
+func (c *LoadingCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.m.Delete(key)
+}
+
+// CacheStats returns a snapshot of the cache's counters. Reads served
+// from the cache count as hits even when they trigger a background
+// reload.
+func (c *LoadingCache[K, V]) CacheStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Size returns the number of cached entries, including stale ones.
+func (c *LoadingCache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.m.Size()
+}
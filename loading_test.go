@@ -0,0 +1,58 @@
+package hashmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoadingCacheRefreshPanic(t *testing.T) {
+	calls := 0
+	c := NewLoadingCache(func(key string) (int, error) {
+		calls++
+		if calls > 1 {
+			panic("loader failed")
+		}
+		return len(key), nil
+	}, RefreshPolicy{RefreshAfter: time.Nanosecond, ErrorBackoff: time.Hour})
+
+	if v, err := c.Get("key"); v != 3 || err != nil {
+		t.Fatalf("Get = %v, %v, want 3, nil", v, err)
+	}
+	time.Sleep(time.Millisecond)
+	if v, err := c.Get("key"); v != 3 || err != nil {
+		t.Fatalf("stale Get = %v, %v, want 3, nil", v, err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		entry, _ := c.m.Get("key")
+		refreshing, retryAt := entry.refreshing, entry.retryAt
+		c.mu.Unlock()
+		if !refreshing {
+			if retryAt.IsZero() {
+				t.Fatal("panicking reload did not back off")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("entry still refreshing after the loader panicked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if stats := c.CacheStats(); stats.LoadFailures != 1 {
+		t.Fatalf("LoadFailures = %d, want 1", stats.LoadFailures)
+	}
+
+	if v, err := c.Get("key"); v != 3 || err != nil || calls != 2 {
+		t.Fatalf("Get during backoff = %v, %v after %d loads, want 3, nil after 2", v, err, calls)
+	}
+}
+
+func TestLoadingCacheReloadError(t *testing.T) {
+	c := NewLoadingCache(func(string) (int, error) { panic("boom") }, RefreshPolicy{RefreshAfter: time.Second})
+	if _, err := c.reload(t.Context(), "key"); !errors.Is(err, ErrLoaderPanic) {
+		t.Fatalf("reload error = %v, want ErrLoaderPanic", err)
+	}
+}
@@ -0,0 +1,45 @@
+package hashmap
+
+import "iter"
+
+// Map is the API shared by HashMap and the other map variants of this
+// package, so that code can accept any backend and tests can swap one
+// for another. HashMap, LinkedHashMap, AtomicMap, CuckooMap, HopscotchMap,
+// FuncMap, BuiltinMap, ExpiringMap, Cache and TieredCache implement it.
+// LoadingCache and StoreCache do not, since their reads and writes can
+// fail.
+//
+// Variants differ in how they compare keys: most match string keys
+// case-insensitively, while FuncMap uses its equality function and
+// BuiltinMap compares exactly. Of these, only AtomicMap and ExpiringMap
+// are safe for concurrent use.
+type Map[K, V any] interface {
+	// Get retrieves the value for a key.
+	// Returns the value and true if found, zero value and false otherwise.
+	Get(key K) (V, bool)
+	// Set inserts or updates a key-value pair.
+	Set(key K, value V)
+	// Delete removes a key-value pair.
+	// Returns true if the key was found and deleted.
+	Delete(key K) bool
+	// Contains checks if a key exists.
+	Contains(key K) bool
+	// Size returns the number of entries.
+	Size() int
+	// Iter returns an iterator over all key-value pairs.
+	Iter() iter.Seq2[K, V]
+}
+
+// The variants listed in the Map doc must keep implementing it.
+var (
+	_ Map[string, int] = (*HashMap[string, int])(nil)
+	_ Map[string, int] = (*LinkedHashMap[string, int])(nil)
+	_ Map[string, int] = (*AtomicMap[string, int])(nil)
+	_ Map[string, int] = (*CuckooMap[string, int])(nil)
+	_ Map[string, int] = (*HopscotchMap[string, int])(nil)
+	_ Map[string, int] = (*FuncMap[string, int])(nil)
+	_ Map[string, int] = (*BuiltinMap[string, int])(nil)
+	_ Map[string, int] = (*ExpiringMap[string, int])(nil)
+	_ Map[string, int] = (*Cache[string, int])(nil)
+	_ Map[string, int] = (*TieredCache[string, int])(nil)
+)
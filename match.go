@@ -0,0 +1,59 @@
+package hashmap
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+// IterMatching returns an iterator over the entries of a string-keyed map
+// whose keys begin with prefix, ignoring case with the same folding rules
+// used for hashing.
+func IterMatching[V any](h *HashMap[string, V], prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		for key, value := range h.Iter() {
+			if traits.HasPrefixFold(key, prefix) {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// IterRegexp returns an iterator over the entries of a string-keyed map
+// whose keys match re. Keys are matched as stored; use the (?i) flag for
+// case-insensitive patterns.
+func IterRegexp[V any](h *HashMap[string, V], re *regexp.Regexp) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		for key, value := range h.Iter() {
+			if re.MatchString(key) {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetLongestSuffix finds the entry for the longest dot-separated suffix of
+// key present in a string-keyed map, such as "example.com" for
+// "a.b.example.com". The key itself counts as a suffix. Suffixes are looked
+// up with the map's key identity. Returns the matched suffix and its value,
+// or false if no suffix of key is present.
+func GetLongestSuffix[V any](h *HashMap[string, V], key string) (string, V, bool) {
+	for {
+		if value, found := h.Get(key); found {
+			return key, value, true
+		}
+
+		i := strings.IndexByte(key, '.')
+		if i < 0 {
+			var zero V
+			return "", zero, false
+		}
+		key = key[i+1:]
+	}
+}
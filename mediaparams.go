@@ -0,0 +1,230 @@
+package hashmap
+
+import (
+	"errors"
+	"iter"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// mediaParam is a parameter stored in MediaParams.
+type mediaParam struct {
+	name  string // Spelling of the name when last set
+	value string
+	seq   int // Sequence number of the last Set, ordering iteration
+}
+
+// MediaParams is a map of media type parameters, as found in
+// Content-Type and Content-Disposition headers. Parameter names are
+// matched case-insensitively. Parameters are stored as they appear on
+// the wire, and Get assembles RFC 2231 extended values and
+// continuations such as filename*0*=UTF-8'en'a%20b and filename*1="c".
+type MediaParams struct {
+	params *HashMap[string, mediaParam] // Parameters by folded name
+	seq    int
+}
+
+// NewMediaParams creates an empty MediaParams.
+func NewMediaParams() *MediaParams {
+	return &MediaParams{params: New[string, mediaParam]()}
+}
+
+// ParseMediaType parses a Content-Type or Content-Disposition value into
+// its lowercased media type and its parameters. Quoted parameter values
+// are unquoted; names, including RFC 2231 suffixes, are kept as written.
+// When a parameter occurs twice, the last occurrence wins.
+func ParseMediaType(v string) (string, *MediaParams, error) {
+	mediaType, rest, _ := strings.Cut(v, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" {
+		return "", nil, errors.New("hashmap: no media type")
+	}
+
+	params := NewMediaParams()
+	for {
+		rest = strings.TrimLeft(rest, " \t;")
+		if rest == "" {
+			return mediaType, params, nil
+		}
+
+		name, value, found := strings.Cut(rest, "=")
+		if !found {
+			return mediaType, params, errors.New("hashmap: media type parameter without value")
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimLeft(value, " \t")
+
+		if strings.HasPrefix(value, `"`) {
+			var err error
+			value, rest, err = unquoteParam(value)
+			if err != nil {
+				return mediaType, params, err
+			}
+		} else {
+			value, rest, _ = strings.Cut(value, ";")
+			value = strings.TrimSpace(value)
+		}
+		params.Set(name, value)
+	}
+}
+
+// unquoteParam reads a quoted string from the start of s, returning its
+// unescaped contents and the remainder of s after the closing quote.
+func unquoteParam(s string) (string, string, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return b.String(), s[i+1:], nil
+		case '\\':
+			i++
+			if i == len(s) {
+				break
+			}
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", errors.New("hashmap: unterminated quoted media type parameter")
+}
+
+// Set sets a parameter as it appears on the wire, replacing any
+// parameter with the same name in any case. For RFC 2231 parameters the
+// name includes its suffix, as in "filename*" or "title*1".
+func (p *MediaParams) Set(name, value string) {
+	p.params.Set(foldKey(name), mediaParam{name: name, value: value, seq: p.seq})
+	p.seq++
+}
+
+// Raw returns a parameter exactly as it was set.
+func (p *MediaParams) Raw(name string) (string, bool) {
+	param, found := p.params.Get(foldKey(name))
+	return param.value, found
+}
+
+// Get returns the decoded value of a parameter. An RFC 2231 extended
+// value (name*) is preferred, then continuations (name*0, name*1, ...,
+// each optionally extended), then the plain value (name). Extended
+// values in charsets other than UTF-8, US-ASCII and ISO-8859-1 are
+// skipped.
+func (p *MediaParams) Get(name string) (string, bool) {
+	key := foldKey(name)
+
+	if raw, found := p.params.Get(key + "*"); found {
+		if value, ok := decodeExtended(raw.value); ok {
+			return value, true
+		}
+	}
+
+	if value, ok := p.continued(key); ok {
+		return value, true
+	}
+
+	param, found := p.params.Get(key)
+	return param.value, found
+}
+
+// continued assembles the RFC 2231 continuations of the parameter with
+// the folded name key.
+func (p *MediaParams) continued(key string) (string, bool) {
+	var b strings.Builder
+	charset := ""
+	for i := 0; ; i++ {
+		prefix := key + "*" + strconv.Itoa(i)
+		if raw, found := p.params.Get(prefix + "*"); found {
+			value := raw.value
+			if i == 0 {
+				var ok bool
+				if charset, value, ok = splitExtended(value); !ok {
+					return "", false
+				}
+			}
+			decoded, err := url.PathUnescape(value)
+			if err != nil {
+				return "", false
+			}
+			b.WriteString(decoded)
+		} else if raw, found := p.params.Get(prefix); found {
+			b.WriteString(raw.value)
+		} else if i == 0 {
+			return "", false
+		} else {
+			break
+		}
+	}
+	return convertCharset(charset, b.String())
+}
+
+// decodeExtended decodes an RFC 2231 extended value: charset'language'
+// followed by percent-encoded bytes.
+func decodeExtended(s string) (string, bool) {
+	charset, value, ok := splitExtended(s)
+	if !ok {
+		return "", false
+	}
+	decoded, err := url.PathUnescape(value)
+	if err != nil {
+		return "", false
+	}
+	return convertCharset(charset, decoded)
+}
+
+// splitExtended splits an RFC 2231 extended value into its charset and
+// its encoded text, dropping the language.
+func splitExtended(s string) (charset, value string, ok bool) {
+	parts := strings.SplitN(s, "'", 3)
+	if len(parts) != 3 {
+		return "", "", false
+	}
+	return parts[0], parts[2], true
+}
+
+// convertCharset converts text decoded from an extended value in the
+// given charset to UTF-8. An empty charset leaves the text unchanged.
+func convertCharset(charset, s string) (string, bool) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "us-ascii":
+		return s, true
+	case "iso-8859-1":
+		b := make([]byte, 0, len(s))
+		for i := 0; i < len(s); i++ {
+			b = utf8.AppendRune(b, rune(s[i]))
+		}
+		return string(b), true
+	default:
+		return "", false
+	}
+}
+
+// Del removes a parameter as it appears on the wire.
+// Returns true if the parameter was found and removed.
+func (p *MediaParams) Del(name string) bool {
+	return p.params.Delete(foldKey(name))
+}
+
+// Len returns the number of parameters as they appear on the wire.
+func (p *MediaParams) Len() int {
+	return p.params.Size()
+}
+
+// All returns an iterator over the raw parameters in the order they were
+// last set.
+func (p *MediaParams) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		params := make([]mediaParam, 0, p.params.Size())
+		for _, param := range p.params.Iter() {
+			params = append(params, param)
+		}
+		slices.SortFunc(params, func(a, b mediaParam) int { return a.seq - b.seq })
+
+		for _, param := range params {
+			if !yield(param.name, param.value) {
+				return
+			}
+		}
+	}
+}
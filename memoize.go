@@ -0,0 +1,68 @@
+package hashmap
+
+import "sync"
+
+// memoCall is a call of a memoized function, shared by concurrent callers
+// with the same key.
+type memoCall[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	done  bool // Whether the call returned rather than panicked
+}
+
+// Memoize returns a function that calls fn once per distinct key and
+// returns the cached result on later calls. Results are kept in a HashMap
+// created with opts, so string keys differing only in case share a result
+// unless opts include WithCaseSensitive. The returned function is not
+// safe for concurrent use; see MemoizeConcurrent.
+func Memoize[K comparable, V any](fn func(K) V, opts ...Option) func(K) V {
+	results := New[K, V](opts...)
+	return func(key K) V {
+		if value, found := results.Get(key); found {
+			return value
+		}
+		value := fn(key)
+		results.Set(key, value)
+		return value
+	}
+}
+
+// MemoizeConcurrent is like Memoize, but the returned function may be
+// called from several goroutines. Concurrent callers with the same
+// uncached key wait for a single call of fn instead of each calling it.
+// If that call panics, nothing is cached and waiting callers retry.
+func MemoizeConcurrent[K comparable, V any](fn func(K) V, opts ...Option) func(K) V {
+	var mu sync.Mutex
+	calls := New[K, *memoCall[V]](opts...)
+
+	var memo func(K) V
+	memo = func(key K) V {
+		mu.Lock()
+		if c, found := calls.Get(key); found {
+			mu.Unlock()
+			c.wg.Wait()
+			if !c.done {
+				return memo(key)
+			}
+			return c.value
+		}
+
+		c := &memoCall[V]{}
+		c.wg.Add(1)
+		calls.Set(key, c)
+		mu.Unlock()
+
+		defer func() {
+			if !c.done {
+				mu.Lock()
+				calls.Delete(key)
+				mu.Unlock()
+			}
+			c.wg.Done()
+		}()
+		c.value = fn(key)
+		c.done = true
+		return c.value
+	}
+	return memo
+}
@@ -0,0 +1,256 @@
+package hashmap
+
+import (
+	"iter"
+	"unsafe"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+const offHeapChunkSize = 1 << 20 // Minimum size of an off-heap memory chunk
+
+// offHeapSlot is a slot of an OffHeapMap's table. It holds no pointers,
+// so the garbage collector does not scan the table.
+type offHeapSlot struct {
+	hash    uint32
+	chunk   uint32 // Index of the chunk holding the key and value
+	off     uint32 // Offset of the key in the chunk; the value follows it
+	klen    uint32
+	vlen    uint32
+	used    bool
+	deleted bool // Whether the slot is a tombstone, as in HashMap
+}
+
+// OffHeapMap is an experimental string to bytes hash table storing its
+// keys and values outside the Go heap, in anonymous memory mappings
+// where the platform supports them. Only the slot metadata lives on the
+// heap and it contains no pointers, so even maps with many millions of
+// entries add little work to garbage collection. Storage is append-only:
+// memory of updated and deleted entries is only reclaimed by Clear or
+// Close. Keys are hashed and compared like HashMap's string keys. The
+// map must be closed with Close to release its memory.
+type OffHeapMap struct {
+	table      []offHeapSlot
+	size       int
+	capacity   int
+	tombstones int      // Number of tombstones in table
+	chunks     [][]byte // Off-heap memory; the last chunk is filled up to used
+	used       int
+	closed     bool
+}
+
+// NewOffHeap creates a new OffHeapMap with the default initial capacity.
+func NewOffHeap() *OffHeapMap {
+	return &OffHeapMap{
+		table:    make([]offHeapSlot, initialCapacity),
+		capacity: initialCapacity,
+	}
+}
+
+// check panics if the map was closed.
+func (m *OffHeapMap) check() {
+	if m.closed {
+		panic("hashmap: off-heap map used after Close")
+	}
+}
+
+// key returns the key stored in a slot, backed by off-heap memory.
+func (m *OffHeapMap) key(s *offHeapSlot) []byte {
+	return m.chunks[s.chunk][s.off : s.off+s.klen]
+}
+
+// keyString returns the key stored in a slot as a string sharing its
+// off-heap memory, which must not be used once the memory is released.
+func (m *OffHeapMap) keyString(s *offHeapSlot) string {
+	k := m.key(s)
+	return unsafe.String(unsafe.SliceData(k), len(k))
+}
+
+// value returns the value stored in a slot, backed by off-heap memory.
+func (m *OffHeapMap) value(s *offHeapSlot) []byte {
+	start := s.off + s.klen
+	return m.chunks[s.chunk][start : start+s.vlen]
+}
+
+// store copies a key and value into off-heap memory, mapping a new chunk
+// if the current one is full, and records their location in s.
+func (m *OffHeapMap) store(s *offHeapSlot, key string, value []byte) {
+	n := len(key) + len(value)
+	if len(m.chunks) == 0 || m.used+n > len(m.chunks[len(m.chunks)-1]) {
+		m.chunks = append(m.chunks, mapMemory(max(n, offHeapChunkSize)))
+		m.used = 0
+	}
+
+	chunk := m.chunks[len(m.chunks)-1]
+	copy(chunk[m.used:], key)
+	copy(chunk[m.used+len(key):], value)
+	s.chunk = uint32(len(m.chunks) - 1)
+	s.off = uint32(m.used)
+	s.klen = uint32(len(key))
+	s.vlen = uint32(len(value))
+	m.used += n
+}
+
+// find locates the slot for a key with the given hash using quadratic
+// probing past tombstones. For a missing key the first tombstone passed
+// is returned. Returns the index and whether the key was found.
+func (m *OffHeapMap) find(key string, hash uint32) (int, bool) {
+	mask := m.capacity - 1
+	idx := int(hash) & mask
+	free := -1
+
+	for count := 1; count <= m.capacity; count++ {
+		s := &m.table[idx]
+		switch {
+		case s.used:
+			if s.hash == hash && traits.EqualFold(m.keyString(s), key) {
+				return idx, true
+			}
+		case !s.deleted:
+			if free >= 0 {
+				return free, false
+			}
+			return idx, false
+		case free < 0:
+			free = idx
+		}
+		idx = (idx + count) & mask
+	}
+
+	if free >= 0 {
+		return free, false
+	}
+	return idx, false
+}
+
+// rehash rehashes all existing elements into a new table, dropping the
+// tombstones, and grows the table if it is too full for another element.
+// Keys and values stay where they are in off-heap memory.
+func (m *OffHeapMap) rehash() {
+	old := m.table
+	if (m.size+1)*maximumLoad >= m.capacity {
+		m.capacity *= 2
+	}
+	m.table = make([]offHeapSlot, m.capacity)
+	m.tombstones = 0
+
+	for _, s := range old {
+		if s.used {
+			idx, _ := m.find(m.keyString(&s), s.hash)
+			m.table[idx] = s
+		}
+	}
+}
+
+// Set inserts or updates a key-value pair, copying both into off-heap
+// memory.
+func (m *OffHeapMap) Set(key string, value []byte) {
+	m.check()
+	if (m.size+m.tombstones+1)*maximumLoad >= m.capacity {
+		m.rehash()
+	}
+
+	hash := traits.CaseFoldingHash(key)
+	idx, found := m.find(key, hash)
+	s := &m.table[idx]
+	if found {
+		key = m.keyString(s)
+	} else {
+		if s.deleted {
+			m.tombstones--
+		}
+		*s = offHeapSlot{hash: hash, used: true}
+		m.size++
+	}
+	m.store(s, key, value)
+}
+
+// Get retrieves a copy of the value for a key.
+// Returns the value and true if found, nil and false otherwise.
+func (m *OffHeapMap) Get(key string) ([]byte, bool) {
+	m.check()
+	idx, found := m.find(key, traits.CaseFoldingHash(key))
+	if !found {
+		return nil, false
+	}
+	return append([]byte(nil), m.value(&m.table[idx])...), true
+}
+
+// Contains checks whether a key exists in the map.
+func (m *OffHeapMap) Contains(key string) bool {
+	m.check()
+	_, found := m.find(key, traits.CaseFoldingHash(key))
+	return found
+}
+
+// Delete removes a key-value pair from the map. The memory it occupied
+// is not reused until Clear.
+// Returns true if the key was found and deleted.
+func (m *OffHeapMap) Delete(key string) bool {
+	m.check()
+	idx, found := m.find(key, traits.CaseFoldingHash(key))
+	if !found {
+		return false
+	}
+
+	m.table[idx] = offHeapSlot{deleted: true}
+	m.size--
+	m.tombstones++
+	return true
+}
+
+// Clear removes all elements from the map and releases its off-heap
+// memory.
+func (m *OffHeapMap) Clear() {
+	m.check()
+	m.release()
+	m.table = make([]offHeapSlot, initialCapacity)
+	m.capacity = initialCapacity
+	m.size, m.tombstones = 0, 0
+}
+
+// release unmaps all off-heap memory.
+func (m *OffHeapMap) release() {
+	for _, chunk := range m.chunks {
+		unmapMemory(chunk)
+	}
+	m.chunks = nil
+	m.used = 0
+}
+
+// Close releases the map's off-heap memory. The map must not be used
+// afterwards.
+func (m *OffHeapMap) Close() error {
+	if !m.closed {
+		m.release()
+		m.table = nil
+		m.closed = true
+	}
+	return nil
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *OffHeapMap) Size() int {
+	return m.size
+}
+
+// Capacity returns the current capacity of the underlying table.
+func (m *OffHeapMap) Capacity() int {
+	return m.capacity
+}
+
+// Iter returns an iterator over key-value pairs. The values are backed
+// by off-heap memory and must not be retained past the next Set, Clear
+// or Close; copy them to keep them.
+func (m *OffHeapMap) Iter() iter.Seq2[string, []byte] {
+	return func(yield func(string, []byte) bool) {
+		m.check()
+		for i := range m.table {
+			if s := &m.table[i]; s.used {
+				if !yield(string(m.key(s)), m.value(s)) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package hashmap
+
+// mapMemory returns n bytes of zeroed memory. Platforms without
+// anonymous mappings fall back to the Go heap.
+func mapMemory(n int) []byte {
+	return make([]byte, n)
+}
+
+// unmapMemory releases memory returned by mapMemory.
+func unmapMemory(mem []byte) {}
@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package hashmap
+
+import "syscall"
+
+// mapMemory returns n bytes of zeroed memory from an anonymous mapping.
+func mapMemory(n int) []byte {
+	mem, err := syscall.Mmap(-1, 0, n, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		panic("hashmap: cannot map off-heap memory: " + err.Error())
+	}
+	return mem
+}
+
+// unmapMemory releases memory returned by mapMemory.
+func unmapMemory(mem []byte) {
+	syscall.Munmap(mem)
+}
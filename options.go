@@ -0,0 +1,253 @@
+package hashmap
+
+import (
+	"errors"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+// Config holds the settings a HashMap is created with. The zero value
+// describes the default map: case-insensitive string hashing with
+// Chromium's seed, a table that doubles as it fills, and no extras.
+type Config struct {
+	Capacity      int           // Number of elements the table holds before growing
+	Seed          uint64        // Seed for hashing string keys; traits.Seed when zero
+	CaseSensitive bool          // Whether string keys hash and compare by their exact bytes
+	BloomFilter   bool          // Whether to keep a Bloom filter over the keys
+	TwoChoice     bool          // Whether to use two-choice insertion, see NewTwoChoice
+	Interner      *Interner     // Optional deduplicator for inserted string keys
+	Growth        *GrowthPolicy // Optional growth policy; tables double when nil
+	Deferred      bool          // Whether Set defers placing entries until Build
+	Timestamps    bool          // Whether to record per-entry timestamps
+	KeyValidation KeyValidation // How Set handles invalid header field names; unchecked when zero
+	HashVersion   int           // HashVersion the hashes must match; unchecked when zero
+	Tracing       *Tracing      // Optional trace instrumentation
+	Probing       Probing       // Probe sequence resolving collisions; quadratic when zero
+	Canonicalize  any           // Optional func(K) K applied to keys, see WithKeyCanonicalizer
+	Hasher        any           // Optional func(K) uint64 hashing keys, see WithHasher
+	RandomOrder   bool          // Whether each iteration starts at a random slot
+	FailFast      bool          // Whether iteration panics if the map is structurally modified
+	MaxLoad       float64       // Load factor at which the table grows; 0.5 when zero
+	Incremental   bool          // Whether rehashing migrates entries incrementally
+}
+
+// Option configures a HashMap created by New.
+type Option func(*Config)
+
+// WithCapacity sizes the table to hold n elements without growing.
+func WithCapacity(n int) Option {
+	return func(c *Config) { c.Capacity = n }
+}
+
+// WithSeed hashes string keys with seed instead of Chromium's seed.
+func WithSeed(seed uint64) Option {
+	return func(c *Config) { c.Seed = seed }
+}
+
+// WithCaseSensitive hashes and compares string keys by their exact bytes
+// instead of folding case first, so keys differing only in case are
+// distinct. This gives up Chromium hash parity.
+func WithCaseSensitive() Option {
+	return func(c *Config) { c.CaseSensitive = true }
+}
+
+// WithBloomFilter maintains a Bloom filter over the keys, see NewWithBloomFilter.
+func WithBloomFilter() Option {
+	return func(c *Config) { c.BloomFilter = true }
+}
+
+// WithTwoChoice enables two-choice insertion, see NewTwoChoice.
+func WithTwoChoice() Option {
+	return func(c *Config) { c.TwoChoice = true }
+}
+
+// WithInterner deduplicates inserted string keys through in, see NewWithInterner.
+func WithInterner(in *Interner) Option {
+	return func(c *Config) { c.Interner = in }
+}
+
+// WithGrowthPolicy grows the table according to p, see NewWithGrowthPolicy.
+func WithGrowthPolicy(p GrowthPolicy) Option {
+	return func(c *Config) { c.Growth = &p }
+}
+
+// WithDeferredBuild makes Set only collect entries until Build places
+// them all at once. This suits maps that are filled completely before
+// they are first read: every key is hashed once, into a table sized for
+// exactly the final number of entries. Until Build the map cannot be
+// read, and Get, Contains, Delete and iteration panic.
+func WithDeferredBuild() Option {
+	return func(c *Config) { c.Deferred = true }
+}
+
+// WithRandomIteration makes every iteration start at a random slot, like
+// the randomized range order of builtin maps, so that tests relying on
+// the order of the table fail early instead of when a rehash changes it.
+// It costs a random number per iteration.
+func WithRandomIteration() Option {
+	return func(c *Config) { c.RandomOrder = true }
+}
+
+// WithFailFastIteration makes iteration panic once the map is
+// structurally modified during it, like Java's fail-fast iterators. Any
+// insertion of a new key, deletion, rehash, Clear, Reset or RotateSeed
+// counts, while updating the value of an existing key does not. The
+// check runs after each entry is yielded, so it catches the first
+// modification made by the loop body. Drain, DeleteFunc and cursors are
+// exempt, as they are meant to be used with modifications.
+func WithFailFastIteration() Option {
+	return func(c *Config) { c.FailFast = true }
+}
+
+// WithMaxLoad grows the table once it is more than load full instead of
+// half full. Denser tables save memory at the cost of longer probe
+// sequences. load must be in (0, 0.9], and above 0.5 only with
+// power-of-two table sizes, since quadratic probing of prime tables is
+// only guaranteed to reach half of the slots.
+func WithMaxLoad(load float64) Option {
+	return func(c *Config) { c.MaxLoad = load }
+}
+
+// WithGrowthFactor multiplies the capacity by factor whenever the table
+// grows, instead of doubling it. It sets the Factor of the growth policy,
+// so it combines with an earlier WithGrowthPolicy but is overridden by a
+// later one.
+func WithGrowthFactor(factor float64) Option {
+	return func(c *Config) {
+		var p GrowthPolicy
+		if c.Growth != nil {
+			p = *c.Growth
+		}
+		p.Factor = factor
+		c.Growth = &p
+	}
+}
+
+// WithIncrementalRehash spreads the cost of rehashing a growing table
+// over the mutations that follow, like Redis does, instead of moving every
+// entry in the Set that triggers it. The new table is allocated at once,
+// and every later Set and Delete migrates a bounded number of slots, as
+// after RotateSeed. Until the migration completes, lookups of keys not
+// migrated yet probe both tables and the Bloom filter is bypassed. This
+// bounds the latency of inserting into large maps at the cost of briefly
+// holding both tables.
+func WithIncrementalRehash() Option {
+	return func(c *Config) { c.Incremental = true }
+}
+
+// Validate reports the first setting of c that New would reject.
+func (c *Config) Validate() error {
+	if c.Capacity < 0 {
+		return errors.New("hashmap: capacity must not be negative")
+	}
+	if c.KeyValidation < 0 || c.KeyValidation > ValidateError {
+		return errors.New("hashmap: unknown key validation mode")
+	}
+	if c.HashVersion != 0 && c.HashVersion != HashVersion {
+		return errors.New("hashmap: hash contract is from an incompatible version")
+	}
+	if err := c.Probing.validate(); err != nil {
+		return err
+	}
+	if c.Tracing != nil && c.Tracing.ProbeThreshold < 0 {
+		return errors.New("hashmap: negative probe threshold")
+	}
+	if c.MaxLoad < 0 || c.MaxLoad > maxMaxLoad {
+		return errors.New("hashmap: maximum load must be in (0, 0.9]")
+	}
+	if c.MaxLoad > defaultMaxLoad && c.Growth != nil && c.Growth.withDefaults().prime() {
+		return errors.New("hashmap: maximum load above 0.5 requires power-of-two table sizes")
+	}
+	if c.Growth != nil {
+		return c.Growth.validate()
+	}
+	return nil
+}
+
+// validate reports whether p, with zero fields defaulted, is usable.
+func (p GrowthPolicy) validate() error {
+	p = p.withDefaults()
+	switch {
+	case p.Factor <= 1:
+		return errors.New("hashmap: growth factor must be greater than 1")
+	case p.MinCapacity < 2:
+		return errors.New("hashmap: minimum capacity must be at least 2")
+	case p.MaxCapacity != 0 && p.MaxCapacity < p.MinCapacity:
+		return errors.New("hashmap: maximum capacity is below minimum capacity")
+	case p.MaxCapacity != 0 && !p.prime() && p.MaxCapacity&(p.MaxCapacity-1) != 0:
+		return errors.New("hashmap: maximum capacity must be a power of two for power-of-two table sizes")
+	case p.ShrinkLoad < 0 || p.ShrinkLoad >= 0.25:
+		return errors.New("hashmap: shrink load must be in [0, 0.25)")
+	}
+	return nil
+}
+
+// prime reports whether p, with zero fields defaulted, uses prime table
+// sizes rather than powers of two.
+func (p GrowthPolicy) prime() bool {
+	return p.Factor != 2 || p.MinCapacity&(p.MinCapacity-1) != 0
+}
+
+// withDefaults returns p with zero fields replaced by their defaults.
+func (p GrowthPolicy) withDefaults() GrowthPolicy {
+	if p.Factor == 0 {
+		p.Factor = 2
+	}
+	if p.MinCapacity == 0 {
+		p.MinCapacity = initialCapacity
+	}
+	return p
+}
+
+// newFromConfig creates a HashMap from a validated configuration.
+func newFromConfig[K comparable, V any](c Config) *HashMap[K, V] {
+	h := &HashMap[K, V]{
+		twoChoice:     c.TwoChoice,
+		interner:      c.Interner,
+		caseSensitive: c.CaseSensitive,
+		seed:          c.Seed,
+		deferred:      c.Deferred,
+		validation:    c.KeyValidation,
+		tracing:       c.Tracing,
+		probing:       c.Probing,
+		randomOrder:   c.RandomOrder,
+		failFast:      c.FailFast,
+		maxLoad:       c.MaxLoad,
+		incremental:   c.Incremental,
+	}
+	if h.seed == 0 {
+		h.seed = traits.Seed
+	}
+	if h.maxLoad == 0 {
+		h.maxLoad = defaultMaxLoad
+	}
+	if c.Canonicalize != nil {
+		canon, ok := c.Canonicalize.(func(K) K)
+		if !ok {
+			panic("hashmap: key canonicalizer does not match the key type")
+		}
+		h.canon = canon
+	}
+	if c.Hasher != nil {
+		hasher, ok := c.Hasher.(func(K) uint64)
+		if !ok {
+			panic("hashmap: hasher does not match the key type")
+		}
+		h.hasher = hasher
+	}
+	if c.Growth != nil {
+		p := c.Growth.withDefaults()
+		h.growth = &p
+		h.prime = p.prime()
+	}
+
+	h.capacity = h.sizedCapacity(c.Capacity)
+	h.table = newSlotTable[K, V](h.capacity)
+	if c.BloomFilter {
+		h.filter = h.newFilter()
+	}
+	if c.Timestamps {
+		h.times = New[K, Metadata](h.keyIdentity()...)
+	}
+	return h
+}
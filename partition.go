@@ -0,0 +1,20 @@
+package hashmap
+
+// Partition splits the map into two new maps in a single pass.
+// Entries for which pred returns true go to matched, all others to rest.
+// Both outputs are configured like the map, without its journal, and
+// presized to hold every entry of the map without rehashing.
+func (h *HashMap[K, V]) Partition(pred func(K, V) bool) (matched, rest *HashMap[K, V]) {
+	matched = h.cloneEmpty(h.size)
+	rest = h.cloneEmpty(h.size)
+
+	for pair := range h.slots() {
+		if pred(pair.Key, pair.Value) {
+			matched.Set(pair.Key, pair.Value)
+		} else {
+			rest.Set(pair.Key, pair.Value)
+		}
+	}
+
+	return matched, rest
+}
@@ -0,0 +1,49 @@
+package hashmap
+
+import "testing"
+
+func TestPartitionKeepsConfig(t *testing.T) {
+	h := New[string, int](
+		WithCaseSensitive(),
+		WithSeed(42),
+		WithBloomFilter(),
+		WithProbing(ProbeLinear),
+		WithGrowthPolicy(GrowthPolicy{Factor: 3, MinCapacity: 7}),
+		WithKeyCanonicalizer(func(s string) string { return s + "!" }),
+	)
+	h.SetJournal(&MemoryJournal[string, int]{})
+	h.Set("a", 1)
+	h.Set("A", 2)
+
+	matched, rest := h.Partition(func(_ string, v int) bool { return v == 1 })
+	for name, m := range map[string]*HashMap[string, int]{"matched": matched, "rest": rest} {
+		switch {
+		case !m.caseSensitive || m.seed != 42 || m.probing != ProbeLinear:
+			t.Errorf("%s: key handling not copied", name)
+		case m.filter == nil:
+			t.Errorf("%s: Bloom filter not copied", name)
+		case m.growth == nil || !m.prime || m.capacity%7 != 0:
+			t.Errorf("%s: growth policy not copied, capacity %d", name, m.capacity)
+		case m.canonical("x") != "x!":
+			t.Errorf("%s: canonicalizer not copied", name)
+		case m.journal != nil:
+			t.Errorf("%s: journal shared with the source map", name)
+		case m.Size() != 1:
+			t.Errorf("%s: size %d, want 1", name, m.Size())
+		}
+	}
+}
+
+func TestPoolKeepsConfig(t *testing.T) {
+	p := NewPool[string, int](100, WithCaseSensitive(), WithCapacity(1))
+	h := p.Get()
+	h.Set("a", 1)
+	h.Set("A", 2)
+	if h.Size() != 2 {
+		t.Fatalf("pooled map has %d keys, want 2 case-sensitive keys", h.Size())
+	}
+	if !h.fits(100, h.capacity) {
+		t.Fatalf("pooled map capacity %d does not hold 100 entries", h.capacity)
+	}
+	p.Put(h)
+}
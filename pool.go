@@ -0,0 +1,49 @@
+package hashmap
+
+import "sync"
+
+const poolMaxGrowth = 8 // Maps grown beyond this factor of their initial capacity are not pooled
+
+// Pool is a pool of reusable HashMaps, presized to hold a given number of
+// entries. Maps are Reset when returned, so their tables are reused
+// instead of reallocated. A map must not be used, and no iterator over it
+// may be resumed, after it has been returned with Put. Values stored in
+// a returned map are cleared, so the pool does not retain them. Maps that
+// grew far beyond their initial size are dropped rather than pooled, so a
+// single outlier does not pin a large table. A Pool is safe for
+// concurrent use.
+type Pool[K comparable, V any] struct {
+	pool     sync.Pool
+	capacity int
+}
+
+// NewPool creates a Pool handing out maps configured by opts that hold
+// size entries without rehashing. A capacity set by opts is ignored. It
+// panics if the options are invalid, like New.
+func NewPool[K comparable, V any](size int, opts ...Option) *Pool[K, V] {
+	opts = append(opts[:len(opts):len(opts)], WithCapacity(size))
+	p := &Pool[K, V]{}
+	p.pool.New = func() any {
+		return New[K, V](opts...)
+	}
+	h := p.Get()
+	p.capacity = h.capacity
+	p.pool.Put(h)
+	return p
+}
+
+// Get returns an empty map from the pool, allocating one if necessary.
+func (p *Pool[K, V]) Get() *HashMap[K, V] {
+	return p.pool.Get().(*HashMap[K, V])
+}
+
+// Put resets a map and returns it to the pool. Any attached journal is
+// detached first, so the reset is not journaled.
+func (p *Pool[K, V]) Put(h *HashMap[K, V]) {
+	if h.capacity > p.capacity*poolMaxGrowth {
+		return
+	}
+	h.SetJournal(nil)
+	h.Reset()
+	p.pool.Put(h)
+}
@@ -0,0 +1,206 @@
+package hashmap
+
+import (
+	"iter"
+	"sort"
+
+	"github.com/nukilabs/hashmap/traits"
+)
+
+// trieNode is a node of a PrefixIndex, reached by a path of folded bytes.
+type trieNode struct {
+	label    byte        // Folded byte leading to this node
+	children []*trieNode // Sorted by label
+	keys     []string    // Keys whose folded form ends at this node
+	count    int         // Number of keys in this subtree
+}
+
+// child returns the child with the given label, or nil.
+func (n *trieNode) child(label byte) *trieNode {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label >= label
+	})
+	if i < len(n.children) && n.children[i].label == label {
+		return n.children[i]
+	}
+	return nil
+}
+
+// childOrCreate returns the child with the given label, creating it if needed.
+func (n *trieNode) childOrCreate(label byte) *trieNode {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label >= label
+	})
+	if i < len(n.children) && n.children[i].label == label {
+		return n.children[i]
+	}
+	c := &trieNode{label: label}
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+	return c
+}
+
+// removeChild unlinks the child with the given label.
+func (n *trieNode) removeChild(label byte) {
+	for i, c := range n.children {
+		if c.label == label {
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// PrefixIndex is a trie over case-folded string keys, answering prefix
+// queries in time proportional to the prefix rather than the map size.
+// It implements Journal, so attaching it to a map with AddJournal, or
+// building it with IndexPrefixes, keeps it in sync with the map's
+// mutations. Prefixes are matched with the same folding rules used for
+// hashing.
+type PrefixIndex[V any] struct {
+	root trieNode
+}
+
+// NewPrefixIndex creates a new, empty PrefixIndex.
+func NewPrefixIndex[V any]() *PrefixIndex[V] {
+	return &PrefixIndex[V]{}
+}
+
+// IndexPrefixes creates a PrefixIndex over the keys of h and attaches it
+// to the map with AddJournal, alongside any journal already attached.
+func IndexPrefixes[V any](h *HashMap[string, V]) *PrefixIndex[V] {
+	p := NewPrefixIndex[V]()
+	for key := range h.Iter() {
+		p.Add(key)
+	}
+	h.AddJournal(p)
+	return p
+}
+
+// Append applies a journaled mutation to the index.
+func (p *PrefixIndex[V]) Append(r Record[string, V]) {
+	switch r.Op {
+	case OpSet:
+		p.Add(r.Key)
+	case OpDelete:
+		p.Remove(r.Key)
+	case OpClear:
+		p.Clear()
+	}
+}
+
+// Add inserts a key into the index. Adding a key already present has no effect.
+func (p *PrefixIndex[V]) Add(key string) {
+	path := make([]*trieNode, 0, len(key)+1)
+	n := &p.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		n = n.childOrCreate(byte(traits.Latin1CaseFoldTable[key[i]]))
+		path = append(path, n)
+	}
+
+	for _, k := range n.keys {
+		if k == key {
+			return
+		}
+	}
+	n.keys = append(n.keys, key)
+	for _, node := range path {
+		node.count++
+	}
+}
+
+// Remove deletes a key from the index.
+// Returns true if the key was present.
+func (p *PrefixIndex[V]) Remove(key string) bool {
+	path := make([]*trieNode, 0, len(key)+1)
+	n := &p.root
+	path = append(path, n)
+	for i := 0; i < len(key) && n != nil; i++ {
+		n = n.child(byte(traits.Latin1CaseFoldTable[key[i]]))
+		path = append(path, n)
+	}
+	if n == nil {
+		return false
+	}
+
+	found := -1
+	for i, k := range n.keys {
+		if k == key {
+			found = i
+			break
+		}
+	}
+	if found < 0 {
+		return false
+	}
+	n.keys = append(n.keys[:found], n.keys[found+1:]...)
+
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].count--
+		if i > 0 && path[i].count == 0 {
+			path[i-1].removeChild(path[i].label)
+		}
+	}
+	return true
+}
+
+// Clear removes all keys from the index.
+func (p *PrefixIndex[V]) Clear() {
+	p.root = trieNode{}
+}
+
+// Len returns the number of keys in the index.
+func (p *PrefixIndex[V]) Len() int {
+	return p.root.count
+}
+
+// find returns the node reached by the folded prefix, or nil.
+func (p *PrefixIndex[V]) find(prefix string) *trieNode {
+	n := &p.root
+	for i := 0; i < len(prefix) && n != nil; i++ {
+		n = n.child(byte(traits.Latin1CaseFoldTable[prefix[i]]))
+	}
+	return n
+}
+
+// HasPrefix reports whether any key begins with prefix.
+func (p *PrefixIndex[V]) HasPrefix(prefix string) bool {
+	n := p.find(prefix)
+	return n != nil && n.count > 0
+}
+
+// Count returns the number of keys beginning with prefix.
+func (p *PrefixIndex[V]) Count(prefix string) int {
+	if n := p.find(prefix); n != nil {
+		return n.count
+	}
+	return 0
+}
+
+// Keys returns an iterator over the keys beginning with prefix, in
+// lexicographic order of their folded forms. Stopping early makes it
+// suitable for producing a limited number of completions.
+func (p *PrefixIndex[V]) Keys(prefix string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if n := p.find(prefix); n != nil {
+			n.walk(yield)
+		}
+	}
+}
+
+// walk yields the keys in the subtree in order.
+// Returns false if yield asked to stop.
+func (n *trieNode) walk(yield func(string) bool) bool {
+	for _, key := range n.keys {
+		if !yield(key) {
+			return false
+		}
+	}
+	for _, c := range n.children {
+		if !c.walk(yield) {
+			return false
+		}
+	}
+	return true
+}
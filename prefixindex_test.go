@@ -0,0 +1,42 @@
+package hashmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrefixIndexWithJournal(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(h *HashMap[string, int]) (*PrefixIndex[int], *MemoryJournal[string, int])
+	}{
+		{"index first", func(h *HashMap[string, int]) (*PrefixIndex[int], *MemoryJournal[string, int]) {
+			p := IndexPrefixes(h)
+			j := &MemoryJournal[string, int]{}
+			h.AddJournal(j)
+			return p, j
+		}},
+		{"journal first", func(h *HashMap[string, int]) (*PrefixIndex[int], *MemoryJournal[string, int]) {
+			j := &MemoryJournal[string, int]{}
+			h.SetJournal(j)
+			return IndexPrefixes(h), j
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New[string, int]()
+			h.Set("Accept", 1)
+			p, j := tt.setup(h)
+			h.Set("Accept-Encoding", 2)
+			h.Set("Age", 3)
+			h.Delete("accept")
+
+			if got := slices.Collect(p.Keys("acc")); !slices.Equal(got, []string{"Accept-Encoding"}) {
+				t.Fatalf("Keys(acc) = %q, want [Accept-Encoding]", got)
+			}
+			if len(j.Records) != 3 {
+				t.Fatalf("journal holds %d records, want 3", len(j.Records))
+			}
+		})
+	}
+}
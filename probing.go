@@ -0,0 +1,55 @@
+package hashmap
+
+import "errors"
+
+// Probing selects the probe sequence a HashMap uses to resolve collisions.
+type Probing uint8
+
+const (
+	// ProbeQuadratic advances by 1, 2, 3, ... slots, visiting the
+	// triangular offsets from the home bucket. It is the default.
+	ProbeQuadratic Probing = iota
+	// ProbeLinear advances one slot at a time. It is the most cache
+	// friendly, but suffers most from clustering.
+	ProbeLinear
+	// ProbeDouble advances by a fixed step derived from the hash with
+	// Chromium's DoubleHash, so keys sharing a home bucket follow
+	// different sequences.
+	ProbeDouble
+)
+
+// WithProbing resolves collisions with the probe sequence p instead of
+// quadratic probing.
+func WithProbing(p Probing) Option {
+	return func(c *Config) { c.Probing = p }
+}
+
+// validate reports whether p is a known probing strategy.
+func (p Probing) validate() error {
+	if p > ProbeDouble {
+		return errors.New("hashmap: unknown probing strategy")
+	}
+	return nil
+}
+
+// doubleHash implements Chromium's DoubleHash, mixing a hash into an
+// independent one.
+func doubleHash(key uint32) uint32 {
+	key = ^key + (key >> 23)
+	key ^= key << 12
+	key ^= key >> 7
+	key ^= key << 2
+	key ^= key >> 20
+	return key
+}
+
+// doubleStep returns the ProbeDouble step for a hash in a table of the
+// given capacity. The step is coprime with the capacity, so the sequence
+// visits every slot: odd for power-of-two capacities, and nonzero modulo
+// prime ones.
+func (h *HashMap[K, V]) doubleStep(hash uint32, capacity int) int {
+	if h.prime {
+		return 1 + int(doubleHash(hash)%uint32(capacity-1))
+	}
+	return int((doubleHash(hash) | 1) & uint32(capacity-1))
+}
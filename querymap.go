@@ -0,0 +1,155 @@
+package hashmap
+
+import (
+	"errors"
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// queryEntry holds the values of one query parameter in a QueryMap.
+type queryEntry struct {
+	key    string
+	values []string
+}
+
+// QueryMap is an ordered multimap of URL query parameters. Unlike header
+// names, parameter names are case-sensitive. Parameters keep the order
+// in which they were first added, and Encode writes them in that order
+// with all values of a parameter together, so the output is stable.
+type QueryMap struct {
+	entries []queryEntry
+	index   *HashMap[string, int] // Position of each parameter in entries
+}
+
+// NewQueryMap creates an empty QueryMap.
+func NewQueryMap() *QueryMap {
+	return &QueryMap{index: New[string, int](WithCaseSensitive())}
+}
+
+// ParseQuery parses a URL-encoded query string such as "a=1&b=2&a=3".
+// Like url.ParseQuery, it rejects parameters containing semicolons and
+// returns the first error encountered, along with every parameter that
+// could be parsed.
+func ParseQuery(query string) (*QueryMap, error) {
+	q := NewQueryMap()
+
+	var err error
+	for part := range strings.SplitSeq(query, "&") {
+		if part == "" {
+			continue
+		}
+		if strings.Contains(part, ";") {
+			if err == nil {
+				err = errors.New("hashmap: invalid semicolon separator in query")
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(part, "=")
+		key, err1 := url.QueryUnescape(key)
+		value, err2 := url.QueryUnescape(value)
+		if e := errors.Join(err1, err2); e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+		q.Add(key, value)
+	}
+	return q, err
+}
+
+// Add appends a value to the parameter with the given name.
+func (q *QueryMap) Add(key, value string) {
+	if i, found := q.index.Get(key); found {
+		q.entries[i].values = append(q.entries[i].values, value)
+		return
+	}
+	q.index.Set(key, len(q.entries))
+	q.entries = append(q.entries, queryEntry{key: key, values: []string{value}})
+}
+
+// Set replaces the values of the parameter with the given name by value.
+// A parameter that already exists keeps its position.
+func (q *QueryMap) Set(key, value string) {
+	if i, found := q.index.Get(key); found {
+		q.entries[i].values = append(q.entries[i].values[:0], value)
+		return
+	}
+	q.Add(key, value)
+}
+
+// Get returns the first value of the parameter with the given name.
+func (q *QueryMap) Get(key string) (string, bool) {
+	i, found := q.index.Get(key)
+	if !found {
+		return "", false
+	}
+	return q.entries[i].values[0], true
+}
+
+// Values returns all values of the parameter with the given name.
+func (q *QueryMap) Values(key string) []string {
+	i, found := q.index.Get(key)
+	if !found {
+		return nil
+	}
+	return slices.Clone(q.entries[i].values)
+}
+
+// Has checks whether a parameter with the given name exists.
+func (q *QueryMap) Has(key string) bool {
+	return q.index.Contains(key)
+}
+
+// Del removes the parameter with the given name.
+// Returns true if the parameter was found and removed.
+func (q *QueryMap) Del(key string) bool {
+	i, found := q.index.Get(key)
+	if !found {
+		return false
+	}
+
+	q.index.Delete(key)
+	q.entries = slices.Delete(q.entries, i, i+1)
+	for j := i; j < len(q.entries); j++ {
+		q.index.Set(q.entries[j].key, j)
+	}
+	return true
+}
+
+// Len returns the number of distinct parameter names.
+func (q *QueryMap) Len() int {
+	return len(q.entries)
+}
+
+// All returns an iterator over name-value pairs in order. A parameter
+// with several values yields one pair per value.
+func (q *QueryMap) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, entry := range q.entries {
+			for _, value := range entry.values {
+				if !yield(entry.key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Encode returns the parameters in URL-encoded form, in order. Unlike
+// url.Values.Encode, parameters are not sorted by name.
+func (q *QueryMap) Encode() string {
+	var b strings.Builder
+	for key, value := range q.All() {
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(key))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(value))
+	}
+	return b.String()
+}
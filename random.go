@@ -0,0 +1,45 @@
+package hashmap
+
+import "math/rand/v2"
+
+const randomProbes = 32 // Random slots tried before falling back to a scan
+
+// RandomEntry returns an entry chosen uniformly at random using r, for
+// sampling-based eviction or load testing. Random slots are tried first,
+// which usually succeeds quickly since tables stay at least partly full;
+// sparse tables fall back to picking a random position in a scan.
+// Returns false if the map is empty.
+func (h *HashMap[K, V]) RandomEntry(r *rand.Rand) (K, V, bool) {
+	if h.deferred {
+		panic("hashmap: map read before Build")
+	}
+	if h.size == 0 {
+		var key K
+		var value V
+		return key, value, false
+	}
+	h.migrate(h.old.len())
+
+	for range randomProbes {
+		if s := h.table.at(r.IntN(h.capacity)); s.used {
+			return s.Key, s.Value, true
+		}
+	}
+
+	n := r.IntN(h.size)
+	for s := range h.slots() {
+		if n == 0 {
+			return s.Key, s.Value, true
+		}
+		n--
+	}
+	panic("unreachable")
+}
+
+// RandomKey returns a key chosen uniformly at random using r, like
+// RandomEntry.
+// Returns false if the map is empty.
+func (h *HashMap[K, V]) RandomKey(r *rand.Rand) (K, bool) {
+	key, _, found := h.RandomEntry(r)
+	return key, found
+}
@@ -0,0 +1,12 @@
+//go:build !purego
+
+package rapidhash
+
+// hash runs the fastest implementation of Hash the CPU supports, chosen
+// here by CPU feature so that one binary suits every machine of a fleet
+// Accelerated implementations must return the same results as
+// hashGeneric, and builds with the purego tag never use them
+// None exists yet, so every CPU runs hashGeneric
+func hash(data []byte, seed uint64) uint64 {
+	return hashGeneric(data, seed)
+}
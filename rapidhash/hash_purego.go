@@ -0,0 +1,9 @@
+//go:build purego
+
+package rapidhash
+
+// hash is hashGeneric, since the purego build tag rules out any
+// implementation specific to the CPU
+func hash(data []byte, seed uint64) uint64 {
+	return hashGeneric(data, seed)
+}
@@ -0,0 +1,143 @@
+// Package rapidhash implements the RapidHash function that backs the
+// string hashes of this module, with helpers for hashing strings,
+// integers and streams without copying them into a byte slice first
+//
+// Hash dispatches to an implementation suited to the CPU; building with
+// the purego tag forces the portable one
+package rapidhash
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"unsafe"
+)
+
+const SEED uint64 = 0xbdd89aa982704029
+
+// kStep separates the per-index seeds of HashK (the 64-bit golden ratio)
+const kStep = 0x9e3779b97f4a7c15
+
+var secret = [3]uint64{
+	0x2d358dccaa6c78a5,
+	0x8bb84b93962eacc9,
+	0x4b33a62ed433d4a3,
+}
+
+func Mul128(a, b uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi
+}
+
+func Mix(a, b uint64) uint64 {
+	lo, hi := Mul128(a, b)
+	return lo ^ hi
+}
+
+// Hash implements the RapidHash algorithm
+// It runs the implementation selected for the CPU, see hash; every
+// implementation returns the same results as the portable hashGeneric
+func Hash(data []byte, seed uint64) uint64 {
+	return hash(data, seed)
+}
+
+// hashGeneric is the portable implementation of Hash
+// Input words are decoded as little-endian regardless of the host byte order,
+// and 128-bit products come from bits.Mul64, which is portable to 32-bit
+// targets, so results are identical on every GOARCH
+func hashGeneric(data []byte, seed uint64) uint64 {
+	length := uint64(len(data))
+	seed ^= Mix(seed^secret[0], secret[1]) ^ length
+
+	var a, b uint64
+
+	if length <= 16 {
+		if length >= 4 {
+			a = (uint64(binary.LittleEndian.Uint32(data[0:4])) << 32) |
+				uint64(binary.LittleEndian.Uint32(data[length-4:]))
+
+			delta := ((length & 24) >> (length >> 3))
+			b = (uint64(binary.LittleEndian.Uint32(data[delta:delta+4])) << 32) |
+				uint64(binary.LittleEndian.Uint32(data[length-4-delta:length-delta]))
+		} else if length > 0 {
+			k := length
+			a = (uint64(data[0]) << 56) | (uint64(data[k>>1]) << 32) | uint64(data[k-1])
+			b = 0
+		} else {
+			a = 0
+			b = 0
+		}
+	} else {
+		i := length
+		if i > 48 {
+			see1 := seed
+			see2 := seed
+
+			for i >= 48 {
+				p := data[length-i:]
+				seed = Mix(binary.LittleEndian.Uint64(p[0:8])^secret[0],
+					binary.LittleEndian.Uint64(p[8:16])^seed)
+				see1 = Mix(binary.LittleEndian.Uint64(p[16:24])^secret[1],
+					binary.LittleEndian.Uint64(p[24:32])^see1)
+				see2 = Mix(binary.LittleEndian.Uint64(p[32:40])^secret[2],
+					binary.LittleEndian.Uint64(p[40:48])^see2)
+				i -= 48
+			}
+			seed ^= see1 ^ see2
+		}
+
+		if i > 16 {
+			p := data[length-i:]
+			seed = Mix(binary.LittleEndian.Uint64(p[0:8])^secret[2],
+				binary.LittleEndian.Uint64(p[8:16])^seed^secret[1])
+			if i > 32 {
+				seed = Mix(binary.LittleEndian.Uint64(p[16:24])^secret[2],
+					binary.LittleEndian.Uint64(p[24:32])^seed)
+			}
+		}
+
+		a = binary.LittleEndian.Uint64(data[length-16 : length-8])
+		b = binary.LittleEndian.Uint64(data[length-8:])
+	}
+
+	a ^= secret[1]
+	b ^= seed
+	lo, hi := Mul128(a, b)
+	return Mix(lo^secret[0]^length, hi^secret[1])
+}
+
+// Sum64String hashes the bytes of s like Hash, without copying them
+// into a byte slice first
+func Sum64String(s string, seed uint64) uint64 {
+	return Hash(unsafe.Slice(unsafe.StringData(s), len(s)), seed)
+}
+
+// Sum64Uint64 hashes the 8-byte little-endian encoding of v like Hash
+// Used for integer keys, so they hash identically on every GOARCH
+func Sum64Uint64(v, seed uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return Hash(buf[:], seed)
+}
+
+// HashK derives k hash values for data from a single pass over it
+// The data is hashed once with SEED, and each value re-mixes that hash
+// with a distinct per-index seed, for Bloom filters and count-min
+// sketches that need several hashes per element
+// It returns nil if k is negative
+func HashK(data []byte, k int) []uint64 {
+	if k < 0 {
+		return nil
+	}
+	out := make([]uint64, k)
+	hashK(data, out)
+	return out
+}
+
+// hashK fills out for HashK, which stays small enough to inline so a
+// constant k does not allocate
+func hashK(data []byte, out []uint64) {
+	h := Hash(data, SEED)
+	for i := range out {
+		out[i] = Mix(h^secret[0], secret[1]^(uint64(i+1)*kStep))
+	}
+}
@@ -0,0 +1,60 @@
+package rapidhash
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSum64String(t *testing.T) {
+	s := strings.Repeat("Sec-Fetch-Mode: navigate\n", 12)
+	for n := range len(s) + 1 {
+		want := Hash([]byte(s[:n]), SEED)
+		if got := Sum64String(s[:n], SEED); got != want {
+			t.Fatalf("Sum64String(%d bytes) = %#x, want %#x", n, got, want)
+		}
+	}
+}
+
+func TestSum64StringDoesNotAllocate(t *testing.T) {
+	s := strings.Repeat("x", 100)
+	allocs := testing.AllocsPerRun(100, func() {
+		Sum64String(s, SEED)
+	})
+	if allocs != 0 {
+		t.Fatalf("Sum64String allocated %v times, want 0", allocs)
+	}
+}
+
+func TestHashK(t *testing.T) {
+	if got := HashK([]byte("key"), -1); got != nil {
+		t.Fatalf("HashK(k=-1) = %v, want nil", got)
+	}
+	if got := HashK([]byte("key"), 0); len(got) != 0 {
+		t.Fatalf("HashK(k=0) = %v, want empty", got)
+	}
+	all := HashK([]byte("key"), 8)
+	seen := make(map[uint64]bool)
+	for i, h := range all {
+		if seen[h] {
+			t.Fatalf("HashK value %d repeats %#x", i, h)
+		}
+		seen[h] = true
+	}
+	for k := 1; k < len(all); k++ {
+		if got := HashK([]byte("key"), k); !slices.Equal(got, all[:k]) {
+			t.Fatalf("HashK(k=%d) = %#x, want prefix %#x", k, got, all[:k])
+		}
+	}
+}
+
+func TestHashMatchesGeneric(t *testing.T) {
+	data := []byte(strings.Repeat("Sec-Fetch-Mode: navigate\n", 12))
+	for n := range len(data) + 1 {
+		for _, seed := range []uint64{0, SEED, 1 << 63} {
+			if got, want := Hash(data[:n], seed), hashGeneric(data[:n], seed); got != want {
+				t.Fatalf("Hash(%d bytes, %#x) = %#x, want %#x", n, seed, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package rapidhash
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+const readerBlocks = 64 // 48-byte blocks read from a stream at a time
+
+// HashReader hashes the remaining contents of r like Hash, reading it in
+// blocks instead of loading it into memory
+// RapidHash mixes the input length in before any data, so the length must
+// be known up front: it is taken from a Len method (bytes.Reader,
+// strings.Reader, bytes.Buffer) or by seeking (os.File). Other readers are
+// read fully into memory
+func HashReader(r io.Reader, seed uint64) (uint64, error) {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return HashReaderN(r, int64(v.Len()), seed)
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			break
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return 0, err
+		}
+		return HashReaderN(r, end-cur, seed)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	return Hash(data, seed), nil
+}
+
+// HashReaderN hashes the next n bytes of r like Hash, reading them in
+// blocks instead of loading them into memory
+// Returns io.ErrUnexpectedEOF if r ends before n bytes were read
+func HashReaderN(r io.Reader, n int64, seed uint64) (uint64, error) {
+	if n <= 48 {
+		data := make([]byte, n)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return 0, unexpected(err)
+		}
+		return Hash(data, seed), nil
+	}
+
+	length := uint64(n)
+	seed ^= Mix(seed^secret[0], secret[1]) ^ length
+
+	// Mirrors the 48-byte loop of Hash, keeping the last 16 bytes of the
+	// latest block since the final step may read them again.
+	buf := make([]byte, readerBlocks*48)
+	var last [16]byte
+	see1, see2 := seed, seed
+	i := length
+	for i >= 48 {
+		chunk := buf[:min(i/48, readerBlocks)*48]
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return 0, unexpected(err)
+		}
+		for p := chunk; len(p) >= 48; p = p[48:] {
+			seed = Mix(binary.LittleEndian.Uint64(p[0:8])^secret[0],
+				binary.LittleEndian.Uint64(p[8:16])^seed)
+			see1 = Mix(binary.LittleEndian.Uint64(p[16:24])^secret[1],
+				binary.LittleEndian.Uint64(p[24:32])^see1)
+			see2 = Mix(binary.LittleEndian.Uint64(p[32:40])^secret[2],
+				binary.LittleEndian.Uint64(p[40:48])^see2)
+		}
+		copy(last[:], chunk[len(chunk)-16:])
+		i -= uint64(len(chunk))
+	}
+	seed ^= see1 ^ see2
+
+	tail := buf[:16+i]
+	copy(tail, last[:])
+	if _, err := io.ReadFull(r, tail[16:]); err != nil {
+		return 0, unexpected(err)
+	}
+
+	if i > 16 {
+		p := tail[16:]
+		seed = Mix(binary.LittleEndian.Uint64(p[0:8])^secret[2],
+			binary.LittleEndian.Uint64(p[8:16])^seed^secret[1])
+		if i > 32 {
+			seed = Mix(binary.LittleEndian.Uint64(p[16:24])^secret[2],
+				binary.LittleEndian.Uint64(p[24:32])^seed)
+		}
+	}
+
+	a := binary.LittleEndian.Uint64(tail[len(tail)-16:len(tail)-8]) ^ secret[1]
+	b := binary.LittleEndian.Uint64(tail[len(tail)-8:]) ^ seed
+	lo, hi := Mul128(a, b)
+	return Mix(lo^secret[0]^length, hi^secret[1]), nil
+}
+
+// unexpected reports a stream ending early as io.ErrUnexpectedEOF
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package rapidhash
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// onlyReader hides every method of a reader but Read
+type onlyReader struct{ io.Reader }
+
+func TestHashReader(t *testing.T) {
+	data := make([]byte, 3*readerBlocks*48+100)
+	rand.New(rand.NewSource(1)).Read(data)
+	file, err := os.Create(filepath.Join(t.TempDir(), "data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	lengths := []int{0, 1, 15, 16, 17, 32, 33, 47, 48, 49, 95, 96, 97, 112, 113,
+		readerBlocks * 48, readerBlocks*48 + 1, readerBlocks*48 + 17, len(data)}
+	for _, n := range lengths {
+		want := Hash(data[:n], SEED)
+		readers := map[string]io.Reader{
+			"bytes.Reader": bytes.NewReader(data[:n]),
+			"plain reader": onlyReader{bytes.NewReader(data[:n])},
+			"file":         io.NewSectionReader(file, 0, int64(n)),
+		}
+		for name, r := range readers {
+			got, err := HashReader(r, SEED)
+			if err != nil {
+				t.Fatalf("HashReader(%s, %d bytes): %v", name, n, err)
+			}
+			if got != want {
+				t.Fatalf("HashReader(%s, %d bytes) = %#x, want %#x", name, n, got, want)
+			}
+		}
+	}
+}
+
+func TestHashReaderNShortInput(t *testing.T) {
+	for _, n := range []int{10, 100, readerBlocks*48 + 5} {
+		r := bytes.NewReader(make([]byte, n-1))
+		if _, err := HashReaderN(r, int64(n), SEED); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("HashReaderN(%d of %d bytes) error = %v, want io.ErrUnexpectedEOF", n-1, n, err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package hashmap
+
+import "math/rand/v2"
+
+const migrationStep = 32 // Old slots migrated per mutation after RotateSeed
+
+// RotateSeed installs a new random seed for hashing string keys, so that
+// long-lived maps can periodically re-randomize their layout. Entries are
+// moved to a table hashed with the new seed incrementally: every later Set
+// and Delete migrates a bounded number of slots, so no single operation
+// pays for rehashing the whole map. Lookups never migrate, which keeps
+// them cheap. Iterating, rotating again, growing or shrinking the table
+// completes a pending migration first.
+// After rotation string keys no longer hash like Chromium's CaseFoldingHash.
+func (h *HashMap[K, V]) RotateSeed() {
+	h.migrateTo(h.capacity, rand.Uint64())
+}
+
+// migrateTo starts moving the entries to a new table of the given
+// capacity hashed with seed, completing a pending migration first.
+func (h *HashMap[K, V]) migrateTo(capacity int, seed uint64) {
+	h.migrate(h.old.len())
+
+	h.mods++
+	old := h.table
+	h.old, h.oldSeed, h.migrated = &old, h.seed, 0
+	h.capacity = capacity
+	h.table = newSlotTable[K, V](h.capacity)
+	h.tombstones = 0
+	h.seed = seed
+	if h.filter != nil {
+		h.filter = h.newFilter()
+	}
+}
+
+// Migrating reports whether entries are still being moved to a new table
+// after RotateSeed or an incremental rehash.
+func (h *HashMap[K, V]) Migrating() bool {
+	return h.old != nil
+}
+
+// migrate moves up to n slots of the old table into the current one.
+// Migrated slots are left in place, so probe chains through them stay
+// intact for the entries not yet migrated. Tombstones are not carried
+// over, since no chain of the new table passes through them.
+func (h *HashMap[K, V]) migrate(n int) {
+	for ; n > 0 && h.migrated < h.old.len(); n-- {
+		s := h.old.at(h.migrated)
+		h.migrated++
+		if !s.used {
+			continue
+		}
+
+		hash := h.hash(s.Key)
+		idx, _ := h.find(s.Key, hash)
+		h.place(idx, *s)
+		if h.filter != nil {
+			h.filter.AddHash(uint64(hash))
+		}
+	}
+
+	if h.old != nil && h.migrated == h.old.len() {
+		h.old, h.migrated = nil, 0
+	}
+}
+
+// lookupOld locates a key that has not been migrated yet, returning its
+// slot in the old table or nil.
+func (h *HashMap[K, V]) lookupOld(key K) *slot[K, V] {
+	idx, found := h.findIn(h.old, h.oldSeed, key, h.hashWith(key, h.oldSeed))
+	if !found || idx < h.migrated {
+		return nil
+	}
+	return h.old.at(idx)
+}
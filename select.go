@@ -0,0 +1,124 @@
+package hashmap
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// MaxBy returns the entry that compares greatest according to cmp,
+// which returns a negative number when a < b, zero when a == b and a
+// positive number when a > b. If several entries are greatest, the
+// first one encountered is returned.
+// Returns false if the map is empty.
+func (h *HashMap[K, V]) MaxBy(cmp func(a, b Pair[K, V]) int) (Pair[K, V], bool) {
+	return h.extremeBy(func(a, b Pair[K, V]) bool {
+		return cmp(a, b) > 0
+	})
+}
+
+// MinBy returns the entry that compares least according to cmp,
+// using the same convention as MaxBy. If several entries are least,
+// the first one encountered is returned.
+// Returns false if the map is empty.
+func (h *HashMap[K, V]) MinBy(cmp func(a, b Pair[K, V]) int) (Pair[K, V], bool) {
+	return h.extremeBy(func(a, b Pair[K, V]) bool {
+		return cmp(a, b) < 0
+	})
+}
+
+// extremeBy returns the entry for which better holds against every
+// other entry, scanning the table once.
+func (h *HashMap[K, V]) extremeBy(better func(a, b Pair[K, V]) bool) (Pair[K, V], bool) {
+	var best *Pair[K, V]
+	for pair := range h.slots() {
+		if best == nil || better(pair.Pair, *best) {
+			best = &pair.Pair
+		}
+	}
+
+	if best == nil {
+		return Pair[K, V]{}, false
+	}
+	return *best, true
+}
+
+// TopN returns the n greatest entries according to less, which reports
+// whether a orders before b, sorted from greatest to least. It keeps a
+// heap of at most n entries while scanning the table, so it needs
+// neither a copy nor a full sort of the map. Fewer than n entries are
+// returned if the map is smaller. It panics if n is negative.
+func (h *HashMap[K, V]) TopN(n int, less func(a, b Pair[K, V]) bool) []Pair[K, V] {
+	if n < 0 {
+		panic("hashmap: negative count")
+	}
+
+	top := &pairHeap[K, V]{pairs: make([]Pair[K, V], 0, min(n, h.size)), less: less}
+	for s := range h.slots() {
+		switch {
+		case top.Len() < n:
+			heap.Push(top, s.Pair)
+		case n > 0 && less(top.pairs[0], s.Pair):
+			top.pairs[0] = s.Pair
+			heap.Fix(top, 0)
+		}
+	}
+
+	result := make([]Pair[K, V], top.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(top).(Pair[K, V])
+	}
+	return result
+}
+
+// pairHeap is a min-heap of entries ordered by less.
+type pairHeap[K comparable, V any] struct {
+	pairs []Pair[K, V]
+	less  func(a, b Pair[K, V]) bool
+}
+
+func (p *pairHeap[K, V]) Len() int           { return len(p.pairs) }
+func (p *pairHeap[K, V]) Less(i, j int) bool { return p.less(p.pairs[i], p.pairs[j]) }
+func (p *pairHeap[K, V]) Swap(i, j int)      { p.pairs[i], p.pairs[j] = p.pairs[j], p.pairs[i] }
+func (p *pairHeap[K, V]) Push(x any)         { p.pairs = append(p.pairs, x.(Pair[K, V])) }
+
+func (p *pairHeap[K, V]) Pop() any {
+	last := p.pairs[len(p.pairs)-1]
+	p.pairs = p.pairs[:len(p.pairs)-1]
+	return last
+}
+
+// ContainsValue reports whether any entry has a value that eq considers
+// equal to v. It scans the table and stops at the first match.
+func (h *HashMap[K, V]) ContainsValue(v V, eq func(a, b V) bool) bool {
+	_, _, found := h.FindByValue(func(value V) bool { return eq(value, v) })
+	return found
+}
+
+// FindByValue returns an entry whose value satisfies pred, scanning the
+// table and stopping at the first match. Which entry is returned when
+// several match is unspecified.
+// Returns false if no value matches.
+func (h *HashMap[K, V]) FindByValue(pred func(V) bool) (K, V, bool) {
+	for s := range h.slots() {
+		if pred(s.Value) {
+			return s.Key, s.Value, true
+		}
+	}
+
+	var key K
+	var value V
+	return key, value, false
+}
+
+// KeysWithValue returns an iterator over the keys of h currently mapped
+// to v, such as every alias of a shared value that is being invalidated.
+// Keys may be deleted from h during iteration.
+func KeysWithValue[K, V comparable](h *HashMap[K, V], v V) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for s := range h.slots() {
+			if s.Value == v && !yield(s.Key) {
+				return
+			}
+		}
+	}
+}
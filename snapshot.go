@@ -0,0 +1,283 @@
+package hashmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// encodeSnapshot returns the map's entries encoded as OpSet records in
+// the format written by EncoderJournal.
+func (h *HashMap[K, V]) encodeSnapshot() ([]byte, error) {
+	var buf bytes.Buffer
+	j := NewEncoderJournal[K, V](&buf)
+	for s := range h.slots() {
+		j.Append(Record[K, V]{Op: OpSet, Key: s.Key, Value: s.Value})
+	}
+	return buf.Bytes(), j.Err()
+}
+
+// WriteSnapshot writes the map's entries to the file at path, in the
+// format written by EncoderJournal. The file is replaced atomically: the
+// snapshot goes to a temporary file in the same directory, which is
+// synced and renamed over path, so a crash never leaves a partial
+// snapshot behind.
+func (h *HashMap[K, V]) WriteSnapshot(path string) error {
+	data, err := h.encodeSnapshot()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces the file at path with data via a temporary
+// file and a rename.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Restore sets the entries of the snapshot at path in the map, on top
+// of the entries it already holds. Nothing is set if the snapshot cannot
+// be read completely.
+func (h *HashMap[K, V]) Restore(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	records, err := DecodeJournal[K, V](bufio.NewReader(f))
+	if err != nil {
+		return err
+	}
+	h.Replay(records)
+	return nil
+}
+
+// tableMagic identifies a table snapshot written by WriteTable.
+const tableMagic = "hashmap table"
+
+// tableHeader starts a table snapshot, describing the table layout.
+type tableHeader struct {
+	Magic      string
+	Contract   HashContract
+	Capacity   int
+	Prime      bool
+	Size       int // Number of entries
+	Tombstones int // Number of tombstones; Size+Tombstones tableEntry values follow
+}
+
+// tableEntry is an occupied slot or a tombstone of a table snapshot.
+type tableEntry[K comparable, V any] struct {
+	Index   int    // Position of the slot in the table
+	Hash    uint32 // Hash of the key, for rebuilding a Bloom filter
+	Key     K
+	Value   V
+	Deleted bool // Whether the slot is a tombstone, which has no key or value
+}
+
+// WriteTable writes the map's table to the file at path, replacing it
+// atomically like WriteSnapshot. Unlike a snapshot, the file records the
+// hash contract, the capacity and the position of every entry and
+// tombstone, so that LoadTable reconstructs the table without hashing or
+// probing for a single key. Timestamps are not recorded.
+func (h *HashMap[K, V]) WriteTable(path string) error {
+	h.migrate(h.old.len())
+	header := tableHeader{
+		Magic:      tableMagic,
+		Contract:   h.HashContract(),
+		Capacity:   h.capacity,
+		Prime:      h.prime,
+		Size:       h.size,
+		Tombstones: h.tombstones,
+	}
+
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	for i := range h.table.len() {
+		s := h.table.at(i)
+		if s.deleted {
+			if err := enc.Encode(tableEntry[K, V]{Index: i, Deleted: true}); err != nil {
+				return err
+			}
+			continue
+		}
+		if !s.used {
+			continue
+		}
+		var hash uint32
+		if h.filter != nil {
+			hash = h.hash(s.Key)
+		}
+		entry := tableEntry[K, V]{Index: i, Hash: hash, Key: s.Key, Value: s.Value}
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// LoadTable creates a HashMap from a file written by WriteTable, placing
+// every entry back at its recorded position, so loading takes time
+// proportional to the file size rather than to rehashing the map. The
+// hash contract, capacity and layout come from the file; opts configure
+// everything else, and must select a growth policy that indexes the
+// table the same way as the map that wrote it. Capacity and deferred
+// build options are ignored, and loaded keys are not interned. Keys not
+// covered by HashContract are placed by hashing them instead. The Bloom
+// filter, if enabled, is rebuilt from hashes recorded in the file and is
+// only complete if the writing map kept a filter as well.
+func LoadTable[K comparable, V any](path string, opts ...Option) (*HashMap[K, V], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := gob.NewDecoder(bufio.NewReader(f))
+	var header tableHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, err
+	}
+	if header.Magic != tableMagic {
+		return nil, errors.New("hashmap: not a table snapshot")
+	}
+	if header.Contract.Version != HashVersion {
+		return nil, errors.New("hashmap: hash contract is from an incompatible version")
+	}
+	if header.Size < 0 || header.Tombstones < 0 || header.Capacity < 1 {
+		return nil, errors.New("hashmap: corrupt table snapshot")
+	}
+
+	h := New[K, V](append(opts, WithHashContract(header.Contract))...)
+	if h.prime != header.Prime {
+		return nil, errors.New("hashmap: growth policy does not match table snapshot")
+	}
+	if !h.fits(header.Size, header.Capacity) {
+		return nil, errors.New("hashmap: table snapshot exceeds the maximum load")
+	}
+	h.deferred, h.pending = false, nil
+	h.capacity = header.Capacity
+	h.table = newSlotTable[K, V](h.capacity)
+	if h.filter != nil {
+		h.filter = h.newFilter()
+	}
+
+	place := portableKeys[K]()
+	for range header.Size + header.Tombstones {
+		var entry tableEntry[K, V]
+		if err := dec.Decode(&entry); err != nil {
+			return nil, err
+		}
+		if !place {
+			if !entry.Deleted {
+				h.set(entry.Key, entry.Value)
+			}
+			continue
+		}
+		if entry.Index < 0 || entry.Index >= h.capacity {
+			return nil, errors.New("hashmap: corrupt table snapshot")
+		}
+		if s := h.table.at(entry.Index); s.used || s.deleted {
+			return nil, errors.New("hashmap: corrupt table snapshot")
+		}
+		if entry.Deleted {
+			h.table.set(entry.Index, slot[K, V]{deleted: true})
+			continue
+		}
+		h.table.set(entry.Index, slot[K, V]{Pair: Pair[K, V]{Key: entry.Key, Value: entry.Value}, used: true})
+		if h.filter != nil {
+			h.filter.AddHash(uint64(entry.Hash))
+		}
+	}
+	if place {
+		h.size, h.tombstones = header.Size, header.Tombstones
+	}
+	return h, nil
+}
+
+// Snapshotter periodically writes snapshots of a map, see StartSnapshots.
+type Snapshotter struct {
+	write func() error
+	stop  chan struct{} // Closed to stop the goroutine
+	done  chan struct{} // Closed when the goroutine has exited
+}
+
+// StartSnapshots starts a background goroutine writing a snapshot of
+// the map to path every interval, like WriteSnapshot, so a restarted
+// process can Restore a warm table. Since HashMap is not safe for
+// concurrent use, the goroutine holds mu while reading the map, and all
+// other uses of the map must hold mu as well. The map is only read under
+// the lock; the file is written after releasing it. Failed snapshots do
+// not stop the goroutine. It panics if interval is not positive.
+func (h *HashMap[K, V]) StartSnapshots(path string, interval time.Duration, mu sync.Locker) *Snapshotter {
+	if interval <= 0 {
+		panic("hashmap: snapshot interval must be positive")
+	}
+
+	s := &Snapshotter{
+		write: func() error {
+			mu.Lock()
+			data, err := h.encodeSnapshot()
+			mu.Unlock()
+			if err != nil {
+				return err
+			}
+			return writeFileAtomic(path, data)
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go s.run(interval)
+	return s
+}
+
+// run writes snapshots every interval until stop is closed.
+func (s *Snapshotter) run(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.write()
+		}
+	}
+}
+
+// Stop stops the background goroutine, waits for it to exit, and writes
+// a final snapshot. It returns the error of that snapshot.
+// Stop must be called only once.
+func (s *Snapshotter) Stop() error {
+	close(s.stop)
+	<-s.done
+	return s.write()
+}
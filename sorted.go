@@ -0,0 +1,76 @@
+package hashmap
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedKeys returns the keys of h in ascending order.
+func SortedKeys[K cmp.Ordered, V any](h *HashMap[K, V]) []K {
+	return sortedKeys(h, func(K) bool { return true })
+}
+
+// SortedIter returns an iterator over the entries of h in ascending key
+// order. The keys are sorted when iteration starts; values are looked up
+// as they are yielded, so keys deleted during iteration are skipped.
+func SortedIter[K cmp.Ordered, V any](h *HashMap[K, V]) iter.Seq2[K, V] {
+	return sortedIter(h, func(K) bool { return true })
+}
+
+// Range returns an iterator over the entries of h whose keys lie between
+// lo and hi inclusive, in ascending key order, like SortedIter. Only the
+// keys in range are sorted.
+func Range[K cmp.Ordered, V any](h *HashMap[K, V], lo, hi K) iter.Seq2[K, V] {
+	return sortedIter(h, func(key K) bool { return key >= lo && key <= hi })
+}
+
+// IterSorted returns an iterator over the entries in the key order given
+// by less, which reports whether a orders before b, like SortedIter. This
+// gives deterministic output for keys that are not cmp.Ordered or that
+// need another order.
+func (h *HashMap[K, V]) IterSorted(less func(a, b K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys := slices.Collect(h.Keys())
+		slices.SortFunc(keys, func(a, b K) int {
+			switch {
+			case less(a, b):
+				return -1
+			case less(b, a):
+				return 1
+			}
+			return 0
+		})
+		h.yieldKeys(keys, yield)
+	}
+}
+
+// sortedKeys returns the keys of h satisfying keep in ascending order.
+func sortedKeys[K cmp.Ordered, V any](h *HashMap[K, V], keep func(K) bool) []K {
+	var keys []K
+	for s := range h.slots() {
+		if keep(s.Key) {
+			keys = append(keys, s.Key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// sortedIter returns an iterator over the entries of h whose keys satisfy
+// keep, in ascending key order.
+func sortedIter[K cmp.Ordered, V any](h *HashMap[K, V], keep func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		h.yieldKeys(sortedKeys(h, keep), yield)
+	}
+}
+
+// yieldKeys yields the entries for keys in order, skipping keys that are
+// no longer in the map.
+func (h *HashMap[K, V]) yieldKeys(keys []K, yield func(K, V) bool) {
+	for _, key := range keys {
+		if s := h.lookup(key); s != nil && !yield(s.Key, s.Value) {
+			return
+		}
+	}
+}
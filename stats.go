@@ -0,0 +1,29 @@
+package hashmap
+
+// CacheStats is a snapshot of a cache's counters.
+type CacheStats struct {
+	Hits         uint64 // Reads that found a value
+	Misses       uint64 // Reads that found no value
+	Loads        uint64 // Loader calls that returned a value
+	LoadFailures uint64 // Loader calls that returned an error
+	Evictions    uint64 // Entries removed to make room or because they expired
+}
+
+// HitRate returns the fraction of reads that found a value, or 0 if
+// there were no reads.
+func (s CacheStats) HitRate() float64 {
+	reads := s.Hits + s.Misses
+	if reads == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(reads)
+}
+
+// recordLoad counts a loader call that failed if err is not nil.
+func (s *CacheStats) recordLoad(err error) {
+	if err != nil {
+		s.LoadFailures++
+	} else {
+		s.Loads++
+	}
+}
@@ -0,0 +1,225 @@
+package hashmap
+
+import "context"
+
+// Store is a backing store a StoreCache reads from and writes to, such as
+// a remote key-value service or a file.
+//
+// The StoreCache passes every key in a single spelling shared by all the
+// keys its cache treats as equal: the key after the cache's key
+// canonicalizer, with string keys case-folded like FoldCase unless the
+// cache is case-sensitive. A store therefore never sees "Accept" and
+// "accept" as different keys.
+type Store[K comparable, V any] interface {
+	// Load returns the value for key and whether it exists.
+	Load(key K) (V, bool, error)
+	// Store sets the value for key.
+	Store(key K, value V) error
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(key K) error
+}
+
+// CtxStore is implemented by a Store whose operations can be canceled.
+// The StoreCache methods taking a context call these instead of the
+// Store methods, so a slow store call stops when the caller's context
+// is done.
+type CtxStore[K comparable, V any] interface {
+	Store[K, V]
+	LoadCtx(ctx context.Context, key K) (V, bool, error)
+	StoreCtx(ctx context.Context, key K, value V) error
+	DeleteCtx(ctx context.Context, key K) error
+}
+
+// WriteMode selects when a StoreCache writes changes to its store.
+type WriteMode int
+
+const (
+	WriteThrough WriteMode = iota // Write to the store before updating the cache
+	WriteBehind                   // Buffer writes until Flush
+)
+
+// StoreCache is a Cache in front of a Store. Reads that miss the cache
+// load from the store and cache the result. Writes go to the store
+// immediately in WriteThrough mode, or are buffered until Flush in
+// WriteBehind mode; buffered writes survive the entry being evicted.
+type StoreCache[K comparable, V any] struct {
+	cache *Cache[K, V]
+	store Store[K, V]
+	mode  WriteMode
+	dirty *HashMap[K, Record[K, V]] // Unflushed writes in WriteBehind mode
+}
+
+// NewStoreCache creates a StoreCache caching store's entries in cache
+// and writing changes according to mode.
+func NewStoreCache[K comparable, V any](cache *Cache[K, V], store Store[K, V], mode WriteMode) *StoreCache[K, V] {
+	return &StoreCache[K, V]{
+		cache: cache,
+		store: store,
+		mode:  mode,
+		dirty: New[K, Record[K, V]](cache.m.keyIdentity()...),
+	}
+}
+
+// Get retrieves the value for a key, loading it from the store if it is
+// not cached. Unflushed writes take precedence over the store.
+// Returns the value and true if found, zero value and false otherwise.
+func (s *StoreCache[K, V]) Get(key K) (V, bool, error) {
+	return s.GetCtx(context.Background(), key)
+}
+
+// GetCtx is like Get, but passes ctx to the store if it is a CtxStore.
+func (s *StoreCache[K, V]) GetCtx(ctx context.Context, key K) (V, bool, error) {
+	if value, found := s.cache.Get(key); found {
+		return value, true, nil
+	}
+
+	if r, found := s.dirty.Get(key); found {
+		if r.Op == OpDelete {
+			var zero V
+			return zero, false, nil
+		}
+		s.cache.Set(key, r.Value)
+		return r.Value, true, nil
+	}
+
+	value, found, err := s.load(ctx, s.cache.m.foldedKey(key))
+	if err != nil || !found {
+		return value, false, err
+	}
+	s.cache.Set(key, value)
+	return value, true, nil
+}
+
+// Set inserts or updates a key-value pair. In WriteThrough mode the
+// cache is only updated if writing to the store succeeded.
+func (s *StoreCache[K, V]) Set(key K, value V) error {
+	return s.SetCtx(context.Background(), key, value)
+}
+
+// SetCtx is like Set, but passes ctx to the store if it is a CtxStore.
+func (s *StoreCache[K, V]) SetCtx(ctx context.Context, key K, value V) error {
+	folded := s.cache.m.foldedKey(key)
+	if s.mode == WriteThrough {
+		if err := s.put(ctx, folded, value); err != nil {
+			return err
+		}
+	} else {
+		s.dirty.Set(folded, Record[K, V]{Op: OpSet, Key: folded, Value: value})
+	}
+	s.cache.Set(key, value)
+	return nil
+}
+
+// SetMany inserts or updates several key-value pairs, see SetManyCtx.
+func (s *StoreCache[K, V]) SetMany(pairs []Pair[K, V]) error {
+	return s.SetManyCtx(context.Background(), pairs)
+}
+
+// SetManyCtx inserts or updates several key-value pairs in order. In
+// WriteThrough mode it stops at the first failed write, or before the
+// next write once ctx is done, and returns that error; the pairs written
+// before remain set. Each write is passed ctx if the store is a
+// CtxStore. In WriteBehind mode all pairs are buffered.
+func (s *StoreCache[K, V]) SetManyCtx(ctx context.Context, pairs []Pair[K, V]) error {
+	for _, pair := range pairs {
+		if s.mode == WriteThrough {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if err := s.SetCtx(ctx, pair.Key, pair.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes a key from the cache and the store. In WriteThrough
+// mode the cache is only updated if deleting from the store succeeded.
+func (s *StoreCache[K, V]) Delete(key K) error {
+	return s.DeleteCtx(context.Background(), key)
+}
+
+// DeleteCtx is like Delete, but passes ctx to the store if it is a
+// CtxStore.
+func (s *StoreCache[K, V]) DeleteCtx(ctx context.Context, key K) error {
+	folded := s.cache.m.foldedKey(key)
+	if s.mode == WriteThrough {
+		if err := s.remove(ctx, folded); err != nil {
+			return err
+		}
+	} else {
+		s.dirty.Set(folded, Record[K, V]{Op: OpDelete, Key: folded})
+	}
+	s.cache.Delete(key)
+	return nil
+}
+
+// Flush writes the buffered changes to the store. It stops at the first
+// error, keeping the writes that were not flushed for the next call.
+// Flush does nothing in WriteThrough mode.
+func (s *StoreCache[K, V]) Flush() error {
+	return s.FlushCtx(context.Background())
+}
+
+// FlushCtx is like Flush, but passes ctx to the store if it is a
+// CtxStore, and stops before the next write once ctx is done.
+func (s *StoreCache[K, V]) FlushCtx(ctx context.Context) error {
+	var flushed []K
+	defer func() {
+		for _, key := range flushed {
+			s.dirty.Delete(key)
+		}
+	}()
+
+	for key, r := range s.dirty.Iter() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var err error
+		if r.Op == OpDelete {
+			err = s.remove(ctx, key)
+		} else {
+			err = s.put(ctx, key, r.Value)
+		}
+		if err != nil {
+			return err
+		}
+		flushed = append(flushed, key)
+	}
+	return nil
+}
+
+// Dirty returns the number of keys with unflushed writes.
+func (s *StoreCache[K, V]) Dirty() int {
+	return s.dirty.Size()
+}
+
+// Cache returns the cache in front of the store.
+func (s *StoreCache[K, V]) Cache() *Cache[K, V] {
+	return s.cache
+}
+
+// load reads a key from the store, passing ctx if it is a CtxStore.
+func (s *StoreCache[K, V]) load(ctx context.Context, key K) (V, bool, error) {
+	if cs, ok := s.store.(CtxStore[K, V]); ok {
+		return cs.LoadCtx(ctx, key)
+	}
+	return s.store.Load(key)
+}
+
+// put writes a key to the store, passing ctx if it is a CtxStore.
+func (s *StoreCache[K, V]) put(ctx context.Context, key K, value V) error {
+	if cs, ok := s.store.(CtxStore[K, V]); ok {
+		return cs.StoreCtx(ctx, key, value)
+	}
+	return s.store.Store(key, value)
+}
+
+// remove deletes a key from the store, passing ctx if it is a CtxStore.
+func (s *StoreCache[K, V]) remove(ctx context.Context, key K) error {
+	if cs, ok := s.store.(CtxStore[K, V]); ok {
+		return cs.DeleteCtx(ctx, key)
+	}
+	return s.store.Delete(key)
+}
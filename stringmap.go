@@ -0,0 +1,189 @@
+package hashmap
+
+import (
+	"iter"
+
+	"github.com/nukilabs/hashmap/internal/stringhasher"
+	"github.com/nukilabs/hashmap/rapidhash"
+	"github.com/nukilabs/hashmap/traits"
+)
+
+const foldBufferSize = 128 // Folded keys up to this many bytes are hashed without allocating
+
+// stringEntry is a slot of a StringMap's flat table.
+type stringEntry struct {
+	key     string
+	value   string
+	used    bool
+	deleted bool // Whether the slot is a tombstone, as in HashMap
+}
+
+// StringMap is a string to string hash table behaving like
+// HashMap[string, string], with its hot paths specialized by hand: entries
+// are stored inline in the table instead of behind pointers, and keys are
+// case-folded into a stack buffer for hashing instead of a fresh slice.
+type StringMap struct {
+	table      []stringEntry
+	size       int
+	capacity   int
+	tombstones int // Number of tombstones in table
+}
+
+// NewStringMap creates a new StringMap with the default initial capacity.
+func NewStringMap() *StringMap {
+	return &StringMap{
+		table:    make([]stringEntry, initialCapacity),
+		capacity: initialCapacity,
+	}
+}
+
+// foldHash computes the same hash as traits.CaseFoldingHash without
+// allocating for short keys.
+func foldHash(s string) uint32 {
+	var buf [foldBufferSize]byte
+	var folded []byte
+	if 2*len(s) <= len(buf) {
+		folded = buf[:2*len(s)]
+	} else {
+		folded = make([]byte, 2*len(s))
+	}
+
+	for i := 0; i < len(s); i++ {
+		f := traits.Latin1CaseFoldTable[s[i]]
+		folded[2*i] = byte(f)
+		folded[2*i+1] = byte(f >> 8)
+	}
+
+	return stringhasher.ComputeHashAndMaskTop8Bits(folded, rapidhash.SEED)
+}
+
+// find locates the slot for a key using quadratic probing past
+// tombstones. For a missing key the first tombstone passed is returned.
+// Returns the index and whether the key was found.
+func (m *StringMap) find(key string) (int, bool) {
+	mask := m.capacity - 1
+	idx := int(foldHash(key)) & mask
+	free := -1
+
+	for count := 1; count <= m.capacity; count++ {
+		entry := &m.table[idx]
+		switch {
+		case entry.used:
+			if traits.EqualFold(entry.key, key) {
+				return idx, true
+			}
+		case !entry.deleted:
+			if free >= 0 {
+				return free, false
+			}
+			return idx, false
+		case free < 0:
+			free = idx
+		}
+		idx = (idx + count) & mask
+	}
+
+	if free >= 0 {
+		return free, false
+	}
+	return idx, false
+}
+
+// rehash rehashes all existing elements into a new table, dropping the
+// tombstones, and grows the table if it is too full for another element.
+func (m *StringMap) rehash() {
+	old := m.table
+	if (m.size+1)*maximumLoad >= m.capacity {
+		m.capacity *= 2
+	}
+	m.table = make([]stringEntry, m.capacity)
+	m.tombstones = 0
+
+	for _, entry := range old {
+		if entry.used {
+			idx, _ := m.find(entry.key)
+			m.table[idx] = entry
+		}
+	}
+}
+
+// Set inserts or updates a key-value pair.
+// If the key exists, only the value is updated.
+// If the key is new, both key and value are inserted.
+func (m *StringMap) Set(key, value string) {
+	if (m.size+m.tombstones+1)*maximumLoad >= m.capacity {
+		m.rehash()
+	}
+
+	idx, found := m.find(key)
+	if found {
+		m.table[idx].value = value
+		return
+	}
+
+	if m.table[idx].deleted {
+		m.tombstones--
+	}
+	m.table[idx] = stringEntry{key: key, value: value, used: true}
+	m.size++
+}
+
+// Get retrieves the value for a key.
+// Returns the value and true if found, empty string and false otherwise.
+func (m *StringMap) Get(key string) (string, bool) {
+	idx, found := m.find(key)
+	if !found {
+		return "", false
+	}
+	return m.table[idx].value, true
+}
+
+// Contains checks whether a key exists in the map.
+func (m *StringMap) Contains(key string) bool {
+	_, found := m.find(key)
+	return found
+}
+
+// Delete removes a key-value pair from the map.
+// Returns true if the key was found and deleted.
+func (m *StringMap) Delete(key string) bool {
+	idx, found := m.find(key)
+	if !found {
+		return false
+	}
+
+	m.table[idx] = stringEntry{deleted: true}
+	m.size--
+	m.tombstones++
+	return true
+}
+
+// Clear removes all elements from the map.
+func (m *StringMap) Clear() {
+	m.table = make([]stringEntry, initialCapacity)
+	m.capacity = initialCapacity
+	m.size, m.tombstones = 0, 0
+}
+
+// Size returns the number of key-value pairs in the map.
+func (m *StringMap) Size() int {
+	return m.size
+}
+
+// Capacity returns the current capacity of the underlying table.
+func (m *StringMap) Capacity() int {
+	return m.capacity
+}
+
+// Iter returns an iterator over key-value pairs.
+func (m *StringMap) Iter() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, entry := range m.table {
+			if entry.used {
+				if !yield(entry.key, entry.value) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package hashmap
+
+import (
+	"context"
+	"sync"
+)
+
+// SyncMap has the method set of sync.Map, backed by a HashMap under a
+// mutex, so code written against sync.Map can switch to this package's
+// key semantics without rewriting call sites: string keys are hashed and
+// compared case-insensitively unless the map is created with
+// WithCaseSensitive. The zero value is an empty map ready to use, and a
+// SyncMap must not be copied after first use.
+//
+// Unlike sync.Map, every operation takes the same lock, so SyncMap does
+// not scale with readers on many cores.
+type SyncMap struct {
+	mu      sync.Mutex
+	m       *HashMap[any, any]  // Created on first Store when nil
+	readers int                 // Range calls iterating m, which writes must copy
+	watch   *watchers[any, any] // Created by the first Watch, journaling m
+}
+
+// NewSyncMap creates a new, empty SyncMap configured by opts, like New.
+func NewSyncMap(opts ...Option) *SyncMap {
+	return &SyncMap{m: New[any, any](opts...)}
+}
+
+// init creates the backing map of a zero SyncMap. The caller must hold
+// the lock.
+func (m *SyncMap) init() {
+	if m.m == nil {
+		m.m = New[any, any]()
+	}
+}
+
+// writable creates the backing map if needed and returns it, first
+// replacing it with a copy if Range calls are iterating it. The caller
+// must hold the lock.
+func (m *SyncMap) writable() *HashMap[any, any] {
+	m.init()
+	if m.readers > 0 {
+		m.m, m.readers = m.m.clone(), 0
+	}
+	return m.m
+}
+
+// Load returns the value stored for a key, or nil if there is none.
+// The ok result reports whether a value was found.
+func (m *SyncMap) Load(key any) (value any, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.m == nil {
+		return nil, false
+	}
+	return m.m.Get(key)
+}
+
+// Store sets the value for a key.
+func (m *SyncMap) Store(key, value any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.writable().Set(key, value)
+}
+
+// LoadOrStore returns the existing value for a key if present. Otherwise
+// it stores and returns the given value. The loaded result is true if the
+// value was loaded, false if stored.
+func (m *SyncMap) LoadOrStore(key, value any) (actual any, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	if actual, loaded = m.m.Get(key); loaded {
+		return actual, true
+	}
+	m.writable().Set(key, value)
+	return value, false
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value
+// if any. The loaded result reports whether the key was present.
+func (m *SyncMap) LoadAndDelete(key any) (value any, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.m == nil {
+		return nil, false
+	}
+	if value, loaded = m.m.Get(key); loaded {
+		m.writable().Delete(key)
+	}
+	return value, loaded
+}
+
+// Delete deletes the value for a key.
+func (m *SyncMap) Delete(key any) {
+	m.LoadAndDelete(key)
+}
+
+// Swap swaps the value for a key and returns the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *SyncMap) Swap(key, value any) (previous any, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	previous, loaded = m.m.Get(key)
+	m.writable().Set(key, value)
+	return previous, loaded
+}
+
+// CompareAndSwap swaps the old and new values for a key if the value
+// stored in the map is equal to old. The old value must be of a
+// comparable type.
+func (m *SyncMap) CompareAndSwap(key, old, new any) (swapped bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.m == nil {
+		return false
+	}
+	return CompareAndSwap(m.writable(), key, old, new)
+}
+
+// CompareAndDelete deletes the entry for a key if its value is equal to
+// old. The old value must be of a comparable type. If there is no
+// current value for the key, CompareAndDelete returns false.
+func (m *SyncMap) CompareAndDelete(key, old any) (deleted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.m == nil {
+		return false
+	}
+	return CompareAndDelete(m.writable(), key, old)
+}
+
+// Range calls f for every key and value until f returns false. Unlike
+// sync.Map, it visits the entries of a point-in-time view of the map,
+// taken when Range is called. f is called without holding the lock, so
+// f may use the map; instead of copying the map up front, the first
+// write while Range is iterating copies it.
+func (m *SyncMap) Range(f func(key, value any) bool) {
+	m.mu.Lock()
+	h := m.m
+	if h != nil {
+		h.migrate(h.old.len())
+		m.readers++
+	}
+	m.mu.Unlock()
+	if h == nil {
+		return
+	}
+
+	defer func() {
+		m.mu.Lock()
+		if m.m == h {
+			m.readers--
+		}
+		m.mu.Unlock()
+	}()
+	for key, value := range h.Iter() {
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+// Clear deletes all the entries.
+func (m *SyncMap) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.m != nil {
+		m.writable().Clear()
+	}
+}
+
+// Watch returns a channel receiving every change to key until ctx is
+// done, when the channel is closed, like AtomicMap.Watch. Events are
+// sent in order, and a receiver loading the key sees the change or a
+// later one.
+func (m *SyncMap) Watch(ctx context.Context, key any) <-chan Event[any] {
+	return watchKey(ctx, m.watchers(), key)
+}
+
+// WatchAll is like Watch, but receives the changes to every key as
+// records.
+func (m *SyncMap) WatchAll(ctx context.Context) <-chan Record[any, any] {
+	return watchAll(ctx, m.watchers())
+}
+
+// watchers returns the watchers of m, creating them and attaching them to
+// the backing map on first use.
+func (m *SyncMap) watchers() *watchers[any, any] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	if m.watch == nil {
+		m.watch = newWatchers(m.m)
+		m.m.AddJournal(m.watch)
+	}
+	return m.watch
+}
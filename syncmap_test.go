@@ -0,0 +1,46 @@
+package hashmap
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSyncMapRangeSnapshot(t *testing.T) {
+	var m SyncMap
+	for i := range 100 {
+		m.Store(i, i)
+	}
+
+	n := 0
+	m.Range(func(key, value any) bool {
+		if n == 0 {
+			for i := range 100 {
+				m.Store(i, -1)
+				m.Store(strconv.Itoa(i), i)
+			}
+		}
+		if value != key {
+			t.Fatalf("Range yielded %v: %v, stored during iteration", key, value)
+		}
+		n++
+		return true
+	})
+	if n != 100 {
+		t.Fatalf("Range visited %d entries, want 100", n)
+	}
+	if v, _ := m.Load(7); v != -1 {
+		t.Fatalf("Load(7) = %v, want -1", v)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for i := range 200 {
+				m.Store(i, i)
+				m.Range(func(key, value any) bool { return true })
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package hashmap
+
+import (
+	"slices"
+	"unsafe"
+)
+
+// slotTable is the slot array of a HashMap. Slots are stored inline when
+// values are at most pointer-sized, so inserting a key does not allocate
+// and probing does not chase pointers. Larger values would make every
+// empty slot as large as a value, so their slots are allocated
+// separately and the table holds pointers to them, like a table of
+// *Pair. Slots must be written through set, never through at.
+type slotTable[K comparable, V any] struct {
+	inline []slot[K, V]
+	boxed  []*slot[K, V] // Used instead of inline for large values; nil entries are empty
+	empty  *slot[K, V]   // Returned by at for nil entries of boxed; never written
+}
+
+// newSlotTable creates a table of n empty slots.
+func newSlotTable[K comparable, V any](n int) slotTable[K, V] {
+	var value V
+	if unsafe.Sizeof(value) <= unsafe.Sizeof(uintptr(0)) {
+		return slotTable[K, V]{inline: make([]slot[K, V], n)}
+	}
+	return slotTable[K, V]{boxed: make([]*slot[K, V], n), empty: new(slot[K, V])}
+}
+
+// len returns the number of slots, zero for a nil table.
+func (t *slotTable[K, V]) len() int {
+	if t == nil {
+		return 0
+	}
+	if t.boxed != nil {
+		return len(t.boxed)
+	}
+	return len(t.inline)
+}
+
+// at returns the slot at index i for reading, or for updating the value
+// of an occupied slot.
+func (t *slotTable[K, V]) at(i int) *slot[K, V] {
+	if t.boxed == nil {
+		return &t.inline[i]
+	}
+	if s := t.boxed[i]; s != nil {
+		return s
+	}
+	return t.empty
+}
+
+// set stores s at index i.
+func (t *slotTable[K, V]) set(i int, s slot[K, V]) {
+	if t.boxed == nil {
+		t.inline[i] = s
+		return
+	}
+	if p := t.boxed[i]; p != nil {
+		*p = s
+		return
+	}
+	p := new(slot[K, V])
+	*p = s
+	t.boxed[i] = p
+}
+
+// same reports whether t and u share the same slots.
+func (t *slotTable[K, V]) same(u *slotTable[K, V]) bool {
+	if t.boxed != nil {
+		return len(u.boxed) > 0 && &t.boxed[0] == &u.boxed[0]
+	}
+	return len(u.inline) > 0 && &t.inline[0] == &u.inline[0]
+}
+
+// clone returns a copy of t whose slots can be changed independently.
+func (t *slotTable[K, V]) clone() slotTable[K, V] {
+	if t.boxed == nil {
+		return slotTable[K, V]{inline: slices.Clone(t.inline)}
+	}
+	c := slotTable[K, V]{boxed: make([]*slot[K, V], len(t.boxed)), empty: t.empty}
+	for i, s := range t.boxed {
+		if s != nil {
+			c.boxed[i] = new(slot[K, V])
+			*c.boxed[i] = *s
+		}
+	}
+	return c
+}
+
+// clear empties every slot.
+func (t *slotTable[K, V]) clear() {
+	clear(t.inline)
+	clear(t.boxed)
+}
@@ -0,0 +1,87 @@
+package hashmap
+
+import (
+	"maps"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestSlotTableLayout(t *testing.T) {
+	if tbl := newSlotTable[string, int](8); tbl.inline == nil || tbl.boxed != nil {
+		t.Error("pointer-sized values are not stored inline")
+	}
+	if tbl := newSlotTable[string, struct{}](8); tbl.inline == nil || tbl.boxed != nil {
+		t.Error("empty values are not stored inline")
+	}
+	if tbl := newSlotTable[string, string](8); tbl.boxed == nil || tbl.inline != nil {
+		t.Error("values larger than a pointer are stored inline")
+	}
+}
+
+func TestHashMapLayouts(t *testing.T) {
+	t.Run("inline", func(t *testing.T) {
+		testLayout(t, func(i int) int { return i })
+	})
+	t.Run("boxed", func(t *testing.T) {
+		testLayout(t, func(i int) [4]int { return [4]int{i, -i, i * i, 1} })
+	})
+}
+
+// testLayout runs the same operations on a HashMap and a builtin map and
+// checks that they agree.
+func testLayout[V comparable](t *testing.T, value func(int) V) {
+	h := New[string, V](WithIncrementalRehash())
+	want := make(map[string]V)
+	check := func(step string) {
+		t.Helper()
+		if got := h.ToMap(); !maps.Equal(got, want) {
+			t.Fatalf("%s: map has %d entries, want %d", step, len(got), len(want))
+		}
+	}
+
+	for i := range 500 {
+		key := "key" + strconv.Itoa(i)
+		h.Set(key, value(i))
+		want[key] = value(i)
+	}
+	check("insert")
+
+	for i := 0; i < 500; i += 3 {
+		key := "key" + strconv.Itoa(i)
+		h.Delete(key)
+		delete(want, key)
+	}
+	check("delete")
+
+	h.RotateSeed()
+	for i := 0; i < 500; i += 2 {
+		key := "KEY" + strconv.Itoa(i)
+		h.Set(key, value(-i))
+		if _, found := want["key"+strconv.Itoa(i)]; found {
+			key = "key" + strconv.Itoa(i) // Updates keep the first spelling
+		}
+		want[key] = value(-i)
+	}
+	check("update during migration")
+
+	c := h.clone()
+	c.Set("key1", value(1000))
+	check("clone")
+
+	path := filepath.Join(t.TempDir(), "table")
+	if err := h.WriteTable(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTable[string, V](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.ToMap(); !maps.Equal(got, want) {
+		t.Fatalf("LoadTable: map has %d entries, want %d", len(got), len(want))
+	}
+
+	h.Reset()
+	clear(want)
+	check("reset")
+}
@@ -0,0 +1,135 @@
+package hashmap
+
+import "iter"
+
+// TieredCache is a map split into a small hot tier in front of a large
+// cold tier. The hot tier is a short array scanned linearly, which is
+// cheaper than probing a large table, and entries are promoted to it when
+// they are read from the cold tier. The hot tier's slots are reused in
+// round-robin order, demoting their previous entries back to the cold
+// tier. This pays off for heavily skewed access patterns, such as the few
+// header names that appear on nearly every request. Every entry lives in
+// exactly one tier.
+type TieredCache[K comparable, V any] struct {
+	hot  []Pair[K, V]
+	next int // Hot slot reused by the next promotion once the tier is full
+	cold *HashMap[K, V]
+}
+
+// NewTieredCache creates a TieredCache whose hot tier holds hotSize
+// entries. It panics if hotSize is less than 1.
+func NewTieredCache[K comparable, V any](hotSize int) *TieredCache[K, V] {
+	if hotSize < 1 {
+		panic("hashmap: hot tier size must be at least 1")
+	}
+	return &TieredCache[K, V]{
+		hot:  make([]Pair[K, V], 0, hotSize),
+		cold: New[K, V](),
+	}
+}
+
+// findHot returns the index of a key in the hot tier, or -1.
+func (c *TieredCache[K, V]) findHot(key K) int {
+	for i := range c.hot {
+		if equalKeys(c.hot[i].Key, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// promote moves an entry from the cold tier into the hot tier, demoting
+// the entry whose slot it takes. The entry keeps the key as stored in the
+// cold tier, so the first-inserted spelling survives promotion.
+func (c *TieredCache[K, V]) promote(pair Pair[K, V]) {
+	c.cold.Delete(pair.Key)
+	if len(c.hot) < cap(c.hot) {
+		c.hot = append(c.hot, pair)
+		return
+	}
+
+	demoted := c.hot[c.next]
+	c.hot[c.next] = pair
+	c.next = (c.next + 1) % len(c.hot)
+	c.cold.Set(demoted.Key, demoted.Value)
+}
+
+// Set inserts or updates a key-value pair. New keys go to the cold tier.
+func (c *TieredCache[K, V]) Set(key K, value V) {
+	if i := c.findHot(key); i >= 0 {
+		c.hot[i].Value = value
+		return
+	}
+	c.cold.Set(key, value)
+}
+
+// Get retrieves the value for a key, promoting it to the hot tier if it
+// was found in the cold tier.
+// Returns the value and true if found, zero value and false otherwise.
+func (c *TieredCache[K, V]) Get(key K) (V, bool) {
+	if i := c.findHot(key); i >= 0 {
+		return c.hot[i].Value, true
+	}
+
+	s := c.cold.lookup(c.cold.canonical(key))
+	if s == nil {
+		var zero V
+		return zero, false
+	}
+	pair := s.Pair
+	c.promote(pair)
+	return pair.Value, true
+}
+
+// Contains checks whether a key exists in either tier without promoting it.
+func (c *TieredCache[K, V]) Contains(key K) bool {
+	return c.findHot(key) >= 0 || c.cold.Contains(key)
+}
+
+// Delete removes a key-value pair from the cache.
+// Returns true if the key was found and deleted.
+func (c *TieredCache[K, V]) Delete(key K) bool {
+	i := c.findHot(key)
+	if i < 0 {
+		return c.cold.Delete(key)
+	}
+
+	last := len(c.hot) - 1
+	c.hot[i] = c.hot[last]
+	c.hot[last] = Pair[K, V]{}
+	c.hot = c.hot[:last]
+	if c.next >= len(c.hot) {
+		c.next = 0
+	}
+	return true
+}
+
+// Clear removes all elements from both tiers.
+func (c *TieredCache[K, V]) Clear() {
+	clear(c.hot)
+	c.hot = c.hot[:0]
+	c.next = 0
+	c.cold.Clear()
+}
+
+// Size returns the number of key-value pairs in the cache.
+func (c *TieredCache[K, V]) Size() int {
+	return len(c.hot) + c.cold.Size()
+}
+
+// Iter returns an iterator over key-value pairs without promoting them,
+// hot tier first.
+func (c *TieredCache[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, pair := range c.hot {
+			if !yield(pair.Key, pair.Value) {
+				return
+			}
+		}
+		for key, value := range c.cold.Iter() {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
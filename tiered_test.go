@@ -0,0 +1,26 @@
+package hashmap
+
+import "testing"
+
+func TestTieredCachePromotionKeepsSpelling(t *testing.T) {
+	c := NewTieredCache[string, int](1)
+	c.Set("Content-Type", 1)
+	c.Set("Accept", 2)
+
+	if v, found := c.Get("content-type"); !found || v != 1 {
+		t.Fatalf("Get = %d, %v, want 1, true", v, found)
+	}
+	if v, found := c.Get("ACCEPT"); !found || v != 2 {
+		t.Fatalf("Get = %d, %v, want 2, true", v, found)
+	}
+	want := map[string]int{"Content-Type": 1, "Accept": 2}
+	for key, value := range c.Iter() {
+		if want[key] != value {
+			t.Fatalf("Iter yielded %q: %d, want the first-inserted spellings of %v", key, value, want)
+		}
+		delete(want, key)
+	}
+	if len(want) != 0 {
+		t.Fatalf("Iter missed %v", want)
+	}
+}
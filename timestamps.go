@@ -0,0 +1,40 @@
+package hashmap
+
+import "time"
+
+// Metadata holds the times recorded for an entry of a map created with
+// WithTimestamps.
+type Metadata struct {
+	Created  time.Time // When the key was inserted
+	Updated  time.Time // When the value was last set
+	Accessed time.Time // When the value was last read by Get, or set
+}
+
+// WithTimestamps records when each entry was created, last updated and
+// last accessed, queryable with Metadata. Timestamps are kept beside the
+// table, so values need not carry them, at the cost of an extra lookup
+// on every Set and Get.
+func WithTimestamps() Option {
+	return func(c *Config) { c.Timestamps = true }
+}
+
+// stamp records that a key was set at now.
+func (h *HashMap[K, V]) stamp(key K, now time.Time) {
+	meta, found := h.times.Get(key)
+	if !found {
+		meta.Created = now
+	}
+	meta.Updated, meta.Accessed = now, now
+	h.times.Set(key, meta)
+}
+
+// Metadata returns the timestamps recorded for a key.
+// Returns false if the key does not exist or the map does not record
+// timestamps.
+func (h *HashMap[K, V]) Metadata(key K) (Metadata, bool) {
+	key = h.canonical(key)
+	if h.times == nil || h.lookup(key) == nil {
+		return Metadata{}, false
+	}
+	return h.times.Get(key)
+}
@@ -0,0 +1,88 @@
+package hashmap
+
+import (
+	"context"
+	"runtime/trace"
+	"time"
+)
+
+// TraceKind identifies what a TraceEvent reports.
+type TraceKind uint8
+
+const (
+	TraceRehash    TraceKind = iota + 1 // The table was rehashed
+	TraceLongProbe                      // A probe reached the threshold
+)
+
+// TraceEvent describes a rehash or long probe of a traced map.
+type TraceEvent struct {
+	Map         string        // Name of the map, see Tracing
+	Kind        TraceKind     // What the event reports
+	Size        int           // Number of entries at the time
+	Capacity    int           // Capacity of the table before a rehash, or of the probed table
+	NewCapacity int           // Capacity after a rehash; zero for probes
+	Probes      int           // Probes taken; zero for rehashes
+	Duration    time.Duration // Time the rehash took; zero for probes
+}
+
+// Tracing configures the instrumentation of a map, see WithTracing.
+type Tracing struct {
+	Name           string           // Name identifying the map in traces and events
+	ProbeThreshold int              // Probes from which a lookup or insert is reported; never when zero
+	Log            func(TraceEvent) // Optional callback receiving every event
+}
+
+// WithTracing instruments the map so that latency spikes can be
+// attributed to it. Every rehash runs in a runtime/trace region named
+// "hashmap.rehash", and probes of at least t.ProbeThreshold slots are
+// logged to the execution trace under the "hashmap.probe" category. Both
+// are labelled with t.Name and also passed to t.Log if set. The callback
+// runs synchronously, so it should be cheap and must not use the map.
+func WithTracing(t Tracing) Option {
+	return func(c *Config) { c.Tracing = &t }
+}
+
+// rehash starts tracing a rehash from the current capacity to capacity
+// of a map holding size entries, returning the function that ends it.
+func (t *Tracing) rehash(size, capacity, newCapacity int) func() {
+	start := time.Now()
+	ctx := context.Background()
+	region := trace.StartRegion(ctx, "hashmap.rehash")
+	if trace.IsEnabled() {
+		trace.Logf(ctx, "hashmap.rehash", "%s: %d entries, capacity %d to %d", t.Name, size, capacity, newCapacity)
+	}
+
+	return func() {
+		region.End()
+		if t.Log != nil {
+			t.Log(TraceEvent{
+				Map:         t.Name,
+				Kind:        TraceRehash,
+				Size:        size,
+				Capacity:    capacity,
+				NewCapacity: newCapacity,
+				Duration:    time.Since(start),
+			})
+		}
+	}
+}
+
+// probe reports a probe of the given length if it reaches the threshold.
+func (t *Tracing) probe(size, capacity, probes int) {
+	if t.ProbeThreshold == 0 || probes < t.ProbeThreshold {
+		return
+	}
+
+	if trace.IsEnabled() {
+		trace.Logf(context.Background(), "hashmap.probe", "%s: %d probes, %d entries, capacity %d", t.Name, probes, size, capacity)
+	}
+	if t.Log != nil {
+		t.Log(TraceEvent{
+			Map:      t.Name,
+			Kind:     TraceLongProbe,
+			Size:     size,
+			Capacity: capacity,
+			Probes:   probes,
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package traits
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Batches smaller than this are hashed on the calling goroutine
+const parallelBatchThreshold = 4096
+
+// HashBatch computes CaseFoldingHashWithSeed for every key
+// Large batches are split across GOMAXPROCS goroutines
+func HashBatch(keys []string, seed uint64) []uint32 {
+	hashes := make([]uint32, len(keys))
+
+	workers := runtime.GOMAXPROCS(0)
+	if len(keys) < parallelBatchThreshold || workers == 1 {
+		hashBatch(keys, hashes, seed)
+		return hashes
+	}
+
+	var wg sync.WaitGroup
+	chunk := (len(keys) + workers - 1) / workers
+	for start := 0; start < len(keys); start += chunk {
+		end := min(start+chunk, len(keys))
+		wg.Go(func() {
+			hashBatch(keys[start:end], hashes[start:end], seed)
+		})
+	}
+	wg.Wait()
+
+	return hashes
+}
+
+// hashBatch hashes keys into the matching positions of hashes
+func hashBatch(keys []string, hashes []uint32, seed uint64) {
+	for i, key := range keys {
+		hashes[i] = CaseFoldingHashWithSeed(key, seed)
+	}
+}
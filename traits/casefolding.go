@@ -1,23 +1,45 @@
 package traits
 
 import (
-	"github.com/nukilabs/hashmap/internal/rapidhash"
 	"github.com/nukilabs/hashmap/internal/stringhasher"
+	"github.com/nukilabs/hashmap/rapidhash"
 )
 
+// Seed is the seed CaseFoldingHash uses, matching Chromium's
+const Seed = rapidhash.SEED
+
 // CaseFoldingHash implements Chromium's CaseFoldingHash
 // Converts strings to lowercase and hashes them
 func CaseFoldingHash(s string) uint32 {
-	input := []byte(s)
-	output := make([]byte, len(input)*2)
+	return CaseFoldingHashWithSeed(s, Seed)
+}
 
-	for i, b := range input {
-		folded := Latin1CaseFoldTable[b]
+// CaseFoldingHashWithSeed is CaseFoldingHash with a caller-provided seed
+// Used where several independent case-folding hashes are needed
+func CaseFoldingHashWithSeed(s string, seed uint64) uint32 {
+	output := make([]byte, len(s)*2)
+
+	for i := 0; i < len(s); i++ {
+		folded := Latin1CaseFoldTable[s[i]]
 		output[i*2] = byte(folded)
 		output[i*2+1] = byte(folded >> 8)
 	}
 
-	return stringhasher.ComputeHashAndMaskTop8Bits(output, rapidhash.SEED)
+	return stringhasher.ComputeHashAndMaskTop8Bits(output, seed)
+}
+
+// HasPrefixFold reports whether s begins with prefix
+// Compares bytes after folding them with Latin1CaseFoldTable, like CaseFoldingHash
+func HasPrefixFold(s, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if Latin1CaseFoldTable[s[i]] != Latin1CaseFoldTable[prefix[i]] {
+			return false
+		}
+	}
+	return true
 }
 
 // Latin1 case folding table
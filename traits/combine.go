@@ -0,0 +1,16 @@
+package traits
+
+import "github.com/nukilabs/hashmap/rapidhash"
+
+// Combine mixes the hash h of one component into the running hash of a
+// composite key
+// Order matters, so components hashed in a different order combine differently
+func Combine(running, h uint64) uint64 {
+	return rapidhash.Mix(running^0x2d358dccaa6c78a5, h^0x8bb84b93962eacc9)
+}
+
+// EqualFold reports whether a and b are equal after folding their bytes
+// with Latin1CaseFoldTable, like CaseFoldingHash
+func EqualFold(a, b string) bool {
+	return len(a) == len(b) && HasPrefixFold(a, b)
+}
@@ -0,0 +1,151 @@
+package hashmap
+
+// Tx buffers mutations to a HashMap so they can be applied together with
+// Commit or discarded with Rollback. Reads through a Tx observe its own
+// pending writes on top of the map's current contents. The map itself is
+// left untouched until Commit.
+type Tx[K comparable, V any] struct {
+	m      *HashMap[K, V]
+	writes []txWrite[K, V]  // Buffered mutations in first-touch order
+	index  *HashMap[K, int] // Position of each key's mutation in writes
+	done   bool
+}
+
+// txWrite is a buffered mutation of a single key.
+type txWrite[K comparable, V any] struct {
+	key     K
+	value   V
+	deleted bool
+}
+
+// Begin starts a transaction on the map.
+func (h *HashMap[K, V]) Begin() *Tx[K, V] {
+	return &Tx[K, V]{
+		m:     h,
+		index: New[K, int](h.keyIdentity()...),
+	}
+}
+
+// WithTransaction runs fn inside a transaction. If fn returns nil the
+// transaction is committed, otherwise it is rolled back and the error is
+// returned, leaving the map untouched.
+func (h *HashMap[K, V]) WithTransaction(fn func(tx *Tx[K, V]) error) error {
+	tx := h.Begin()
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// check panics if the transaction has already been committed or rolled back.
+func (t *Tx[K, V]) check() {
+	if t.done {
+		panic("hashmap: transaction already finished")
+	}
+}
+
+// write buffers a mutation, replacing any earlier one for the same key.
+func (t *Tx[K, V]) write(w txWrite[K, V]) {
+	if i, found := t.index.Get(w.key); found {
+		t.writes[i] = w
+		return
+	}
+	t.index.Set(w.key, len(t.writes))
+	t.writes = append(t.writes, w)
+}
+
+// Set buffers inserting or updating a key-value pair.
+func (t *Tx[K, V]) Set(key K, value V) {
+	t.check()
+	t.write(txWrite[K, V]{key: t.m.canonical(key), value: value})
+}
+
+// Get retrieves the value for a key as seen by the transaction.
+// Returns the value and true if found, zero value and false otherwise.
+func (t *Tx[K, V]) Get(key K) (V, bool) {
+	t.check()
+	key = t.m.canonical(key)
+	if i, found := t.index.Get(key); found {
+		w := t.writes[i]
+		if w.deleted {
+			var zero V
+			return zero, false
+		}
+		return w.value, true
+	}
+	return t.m.Get(key)
+}
+
+// Contains checks whether a key exists as seen by the transaction.
+func (t *Tx[K, V]) Contains(key K) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Delete buffers removing a key-value pair.
+// Returns true if the key existed as seen by the transaction.
+func (t *Tx[K, V]) Delete(key K) bool {
+	found := t.Contains(key)
+	t.write(txWrite[K, V]{key: t.m.canonical(key), deleted: true})
+	return found
+}
+
+// Commit applies all buffered mutations to the map and finishes the
+// transaction. The mutations are applied all or nothing: keys rejected
+// by key validation and a table that cannot grow to the final size are
+// detected before the map is touched. A rejected key panics with
+// ValidatePanic, and with ValidateError the whole transaction is
+// discarded and the error retained for Err. A table beyond its maximum
+// capacity panics.
+func (t *Tx[K, V]) Commit() {
+	t.check()
+	h := t.m
+	size := h.Size()
+	for _, w := range t.writes {
+		found := h.Contains(w.key)
+		switch {
+		case w.deleted:
+			if found {
+				size--
+			}
+		case h.rejected(w.key):
+			t.finish()
+			return
+		case !found:
+			size++
+		}
+	}
+	if !h.fits(size, h.sizedCapacity(size)) {
+		panic("hashmap: maximum capacity exceeded")
+	}
+
+	// Deleting first keeps the table at or below its final size, which
+	// Reserve has made room for, so applying the writes cannot fail.
+	h.Reserve(size)
+	for _, w := range t.writes {
+		if w.deleted {
+			h.Delete(w.key)
+		}
+	}
+	for _, w := range t.writes {
+		if !w.deleted {
+			h.Set(w.key, w.value)
+		}
+	}
+	t.finish()
+}
+
+// Rollback discards all buffered mutations and finishes the transaction.
+func (t *Tx[K, V]) Rollback() {
+	t.check()
+	t.finish()
+}
+
+// finish marks the transaction as done and releases its buffers.
+func (t *Tx[K, V]) finish() {
+	t.done = true
+	t.writes = nil
+	t.index = nil
+}
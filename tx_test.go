@@ -0,0 +1,100 @@
+package hashmap
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestTxCommitIsAtomic(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		writes    func(tx *Tx[string, int])
+		wantPanic bool
+		wantErr   bool
+	}{
+		{
+			name: "invalid key panics",
+			opts: []Option{WithHeaderKeys(ValidatePanic)},
+			writes: func(tx *Tx[string, int]) {
+				tx.Set("Accept", 10)
+				tx.Delete("Host")
+				tx.Set("bad key", 11)
+			},
+			wantPanic: true,
+		},
+		{
+			name: "invalid key is retained",
+			opts: []Option{WithHeaderKeys(ValidateError)},
+			writes: func(tx *Tx[string, int]) {
+				tx.Set("Accept", 10)
+				tx.Set("bad key", 11)
+				tx.Delete("Host")
+			},
+			wantErr: true,
+		},
+		{
+			name: "table cannot grow",
+			opts: []Option{WithGrowthPolicy(GrowthPolicy{MinCapacity: 8, MaxCapacity: 16})},
+			writes: func(tx *Tx[string, int]) {
+				tx.Set("Accept", 10)
+				tx.Delete("Host")
+				for _, key := range []string{"A", "B", "C", "D"} {
+					tx.Set(key, 0)
+				}
+			},
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New[string, int](tt.opts...)
+			for i, key := range []string{"Host", "Accept", "Cookie", "Origin", "Referer"} {
+				h.Set(key, i)
+			}
+			before := h.ToMap()
+
+			tx := h.Begin()
+			tt.writes(tx)
+			panicked := func() (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+				tx.Commit()
+				return false
+			}()
+
+			if panicked != tt.wantPanic {
+				t.Fatalf("Commit panicked = %v, want %v", panicked, tt.wantPanic)
+			}
+			if err := h.Err(); (err != nil) != tt.wantErr || err != nil && !errors.Is(err, ErrInvalidKey) {
+				t.Fatalf("Err() = %v, want error: %v", err, tt.wantErr)
+			}
+			if after := h.ToMap(); !maps.Equal(after, before) {
+				t.Fatalf("map changed by failed commit: got %v, want %v", after, before)
+			}
+		})
+	}
+}
+
+func TestTxCommit(t *testing.T) {
+	h := New[string, int]()
+	h.Set("Host", 1)
+	h.Set("Accept", 2)
+
+	tx := h.Begin()
+	tx.Set("accept", 3)
+	tx.Delete("HOST")
+	tx.Set("Cookie", 4)
+	tx.Delete("Cookie")
+	tx.Set("Origin", 5)
+	if _, found := h.Get("Origin"); found {
+		t.Fatal("write visible before Commit")
+	}
+	tx.Commit()
+
+	want := map[string]int{"Accept": 3, "Origin": 5}
+	if got := h.ToMap(); !maps.Equal(got, want) {
+		t.Fatalf("after Commit got %v, want %v", got, want)
+	}
+}
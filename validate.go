@@ -0,0 +1,81 @@
+package hashmap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidKey is reported for keys rejected by key validation.
+var ErrInvalidKey = errors.New("hashmap: invalid header field name")
+
+// KeyValidation selects how a map created with WithHeaderKeys handles
+// keys that are not valid HTTP field names.
+type KeyValidation int
+
+const (
+	ValidatePanic KeyValidation = iota + 1 // Set panics with an error wrapping ErrInvalidKey
+	ValidateError                          // Set ignores the key and retains the error for Err
+)
+
+// WithHeaderKeys makes Set check that string keys are valid HTTP field
+// names, tokens as defined by RFC 9110, so that malformed names that
+// could be used for request smuggling are caught when they are stored.
+// Invalid keys are handled according to mode; TrySet reports them
+// directly in either mode.
+func WithHeaderKeys(mode KeyValidation) Option {
+	return func(c *Config) { c.KeyValidation = mode }
+}
+
+// ValidHeaderName reports whether s is a valid HTTP field name: a
+// non-empty token of letters, digits and the characters !#$%&'*+-.^_`|~.
+func ValidHeaderName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isTokenChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTokenChar reports whether c may appear in an RFC 9110 token.
+func isTokenChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	switch c {
+	case '!', '#', '$', '%', '&', '\'', '*', '+', '-', '.', '^', '_', '`', '|', '~':
+		return true
+	}
+	return false
+}
+
+// checkKey returns an error if key validation is enabled and rejects key.
+func (h *HashMap[K, V]) checkKey(key K) error {
+	if h.validation == 0 {
+		return nil
+	}
+	if s, ok := any(key).(string); ok && !ValidHeaderName(s) {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, s)
+	}
+	return nil
+}
+
+// TrySet is like Set, but returns an error wrapping ErrInvalidKey instead
+// of storing a key rejected by key validation.
+func (h *HashMap[K, V]) TrySet(key K, value V) error {
+	if err := h.checkKey(h.canonical(key)); err != nil {
+		return err
+	}
+	h.Set(key, value)
+	return nil
+}
+
+// Err returns the error for the first key Set ignored in ValidateError
+// mode, or nil.
+func (h *HashMap[K, V]) Err() error {
+	return h.keyErr
+}
@@ -0,0 +1,159 @@
+package hashmap
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Event is a change to a watched key, see AtomicMap.Watch.
+type Event[V any] struct {
+	Op    Op // OpSet, OpDelete or OpClear
+	Value V  // New value for OpSet
+}
+
+// watcher is a subscription to the changes of a map. Records are queued
+// and sent from the watcher's own goroutine, so that writers never block
+// on a receiver that falls behind.
+type watcher[K comparable, V any] struct {
+	mu    sync.Mutex
+	queue []Record[K, V]
+	wake  chan struct{} // Signaled when queue becomes non-empty
+}
+
+// push queues a record for delivery.
+func (w *watcher[K, V]) push(r Record[K, V]) {
+	w.mu.Lock()
+	w.queue = append(w.queue, r)
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers queued records with send until ctx is done or send fails.
+func (w *watcher[K, V]) run(ctx context.Context, send func(Record[K, V]) bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		}
+
+		w.mu.Lock()
+		batch := w.queue
+		w.queue = nil
+		w.mu.Unlock()
+		for _, r := range batch {
+			if !send(r) {
+				return
+			}
+		}
+	}
+}
+
+// watchers is the set of watchers of a map. It is a Journal, so that
+// attaching it to a map passes the map's changes on to the watchers.
+type watchers[K comparable, V any] struct {
+	mu    sync.Mutex
+	all   []*watcher[K, V]              // Watchers of every key
+	keyed *HashMap[K, []*watcher[K, V]] // Watchers of single keys
+	canon func(K) K                     // Canonical form of watched keys
+}
+
+// newWatchers creates an empty set of watchers for keys of h.
+func newWatchers[K comparable, V any](h *HashMap[K, V]) *watchers[K, V] {
+	return &watchers[K, V]{
+		keyed: New[K, []*watcher[K, V]](h.keyIdentity()...),
+		canon: h.canonical,
+	}
+}
+
+// Append passes a change on to the watchers it concerns. A Clear
+// concerns every watcher.
+func (ws *watchers[K, V]) Append(r Record[K, V]) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for _, w := range ws.all {
+		w.push(r)
+	}
+	if r.Op == OpClear {
+		for keyed := range ws.keyed.Values() {
+			for _, w := range keyed {
+				w.push(r)
+			}
+		}
+		return
+	}
+	keyed, _ := ws.keyed.Get(r.Key)
+	for _, w := range keyed {
+		w.push(r)
+	}
+}
+
+// watch adds a watcher of key, or of every key if key is nil, that
+// delivers records with send until ctx is done or send fails, and then
+// calls done.
+func (ws *watchers[K, V]) watch(ctx context.Context, key *K, send func(Record[K, V]) bool, done func()) {
+	w := &watcher[K, V]{wake: make(chan struct{}, 1)}
+	var k K
+	ws.mu.Lock()
+	if key == nil {
+		ws.all = append(ws.all, w)
+	} else {
+		k = ws.canon(*key)
+		keyed, _ := ws.keyed.Get(k)
+		ws.keyed.Set(k, append(keyed, w))
+	}
+	ws.mu.Unlock()
+
+	go func() {
+		defer done()
+		w.run(ctx, send)
+
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		isW := func(x *watcher[K, V]) bool { return x == w }
+		if key == nil {
+			ws.all = slices.DeleteFunc(ws.all, isW)
+			return
+		}
+		keyed, _ := ws.keyed.Get(k)
+		if keyed = slices.DeleteFunc(keyed, isW); len(keyed) == 0 {
+			ws.keyed.Delete(k)
+		} else {
+			ws.keyed.Set(k, keyed)
+		}
+	}()
+}
+
+// watchKey adds a watcher of key sending Events on the returned channel,
+// which is closed once ctx is done.
+func watchKey[K comparable, V any](ctx context.Context, ws *watchers[K, V], key K) <-chan Event[V] {
+	ch := make(chan Event[V])
+	ws.watch(ctx, &key, func(r Record[K, V]) bool {
+		select {
+		case ch <- Event[V]{Op: r.Op, Value: r.Value}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch
+}
+
+// watchAll adds a watcher of every key sending Records on the returned
+// channel, which is closed once ctx is done.
+func watchAll[K comparable, V any](ctx context.Context, ws *watchers[K, V]) <-chan Record[K, V] {
+	ch := make(chan Record[K, V])
+	ws.watch(ctx, nil, func(r Record[K, V]) bool {
+		select {
+		case ch <- r:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch
+}
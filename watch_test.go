@@ -0,0 +1,126 @@
+package hashmap
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// receive returns the next value from ch, failing the test if none
+// arrives in time.
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Fatal("channel closed")
+		}
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+	panic("unreachable")
+}
+
+// closed fails the test unless ch is closed in time.
+func closed[T any](t *testing.T, ch <-chan T) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("channel not closed")
+		}
+	}
+}
+
+func TestAtomicMapWatch(t *testing.T) {
+	m := NewAtomic[string, int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	events := m.Watch(ctx, "Accept")
+	all := m.WatchAll(ctx)
+
+	m.Set("other", 1)
+	m.Set("ACCEPT", 2)
+	if e := receive(t, events); e.Op != OpSet || e.Value != 2 {
+		t.Fatalf("event = %+v, want set 2", e)
+	}
+	if v, _ := m.Get("accept"); v != 2 {
+		t.Fatalf("Get after event = %v, want 2", v)
+	}
+	if m.Delete("missing") {
+		t.Fatal("Delete of a missing key reported true")
+	}
+	m.Delete("accept")
+	if e := receive(t, events); e.Op != OpDelete {
+		t.Fatalf("event = %+v, want delete", e)
+	}
+	m.Clear()
+	if e := receive(t, events); e.Op != OpClear {
+		t.Fatalf("event = %+v, want clear", e)
+	}
+
+	want := []Record[string, int]{
+		{Op: OpSet, Key: "other", Value: 1},
+		{Op: OpSet, Key: "ACCEPT", Value: 2},
+		{Op: OpDelete, Key: "ACCEPT"},
+		{Op: OpClear},
+	}
+	for _, w := range want {
+		if r := receive(t, all); r != w {
+			t.Fatalf("record = %+v, want %+v", r, w)
+		}
+	}
+
+	cancel()
+	closed(t, events)
+	closed(t, all)
+	m.Set("accept", 3)
+}
+
+func TestSyncMapWatch(t *testing.T) {
+	var m SyncMap
+	ctx, cancel := context.WithCancel(context.Background())
+	events := m.Watch(ctx, "key")
+	all := m.WatchAll(ctx)
+
+	m.Store("other", 1)
+	m.Store("key", 2)
+	if e := receive(t, events); e.Op != OpSet || e.Value != 2 {
+		t.Fatalf("event = %+v, want set 2", e)
+	}
+	m.LoadAndDelete("key")
+	if e := receive(t, events); e.Op != OpDelete {
+		t.Fatalf("event = %+v, want delete", e)
+	}
+	for _, key := range []any{"other", "key", "key"} {
+		if r := receive(t, all); r.Key != key {
+			t.Fatalf("record key = %v, want %v", r.Key, key)
+		}
+	}
+
+	cancel()
+	closed(t, events)
+	closed(t, all)
+	m.Store("key", 3)
+}
+
+func TestWatchSlowReceiver(t *testing.T) {
+	m := NewAtomic[int, int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := m.Watch(ctx, 1)
+
+	for i := range 1000 {
+		m.Set(1, i)
+	}
+	for i := range 1000 {
+		if e := receive(t, events); e.Value != i {
+			t.Fatalf("event %d = %+v, want set %d", i, e, i)
+		}
+	}
+}